@@ -0,0 +1,125 @@
+package voldiscoverers
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/voldriver"
+	"code.cloudfoundry.org/voldriver/driverhttp"
+)
+
+const (
+	defaultDockerPluginSocketDir = "/run/docker/plugins"
+	volumeDriverImplements       = "VolumeDriver"
+
+	// unixSocketBaseURL is a placeholder base URL for plugins reached over a
+	// unix socket. net/http's Transport.RoundTrip rejects any request scheme
+	// other than http/https before DialContext ever runs, so the request
+	// must be addressed as plain http; unixSocketHTTPClient's DialContext
+	// ignores the host/addr entirely and always dials the real socket path.
+	unixSocketBaseURL = "http://unix"
+)
+
+// DockerSocketDiscoverer finds Docker volume plugins that advertise
+// themselves as unix sockets under dirs (default /run/docker/plugins),
+// rather than the .json/.spec files NewDockerDriverDiscoverer reads. This is
+// how plugins like rexray and openstorage typically register themselves.
+type DockerSocketDiscoverer struct {
+	logger lager.Logger
+	dirs   []string
+}
+
+func NewDockerSocketDiscoverer(logger lager.Logger, dirs []string) *DockerSocketDiscoverer {
+	if len(dirs) == 0 {
+		dirs = []string{defaultDockerPluginSocketDir}
+	}
+
+	return &DockerSocketDiscoverer{
+		logger: logger.Session("docker-socket-discoverer"),
+		dirs:   dirs,
+	}
+}
+
+func (d *DockerSocketDiscoverer) Discover(logger lager.Logger) (map[string]voldriver.Driver, error) {
+	logger = logger.Session("discover")
+	logger.Info("start")
+	defer logger.Info("end")
+
+	drivers := map[string]voldriver.Driver{}
+
+	for _, dir := range d.dirs {
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			logger.Error("failed-reading-socket-dir", err, lager.Data{"dir": dir})
+			continue
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sock") {
+				continue
+			}
+
+			pluginId := strings.TrimSuffix(entry.Name(), ".sock")
+			socketPath := filepath.Join(dir, entry.Name())
+
+			driver, err := d.activate(logger, socketPath)
+			if err != nil {
+				logger.Error("failed-activating-plugin", err, lager.Data{"pluginId": pluginId, "socket": socketPath})
+				continue
+			}
+			if driver == nil {
+				continue
+			}
+
+			drivers[pluginId] = driver
+		}
+	}
+
+	return drivers, nil
+}
+
+// activate dials socketPath and POSTs Plugin.Activate, returning a driver
+// that talks over the socket only if the plugin reports it implements
+// VolumeDriver -- Docker sockets are also used for network and auth
+// plugins, which volman has no business mounting.
+func (d *DockerSocketDiscoverer) activate(logger lager.Logger, socketPath string) (voldriver.Driver, error) {
+	driver := driverhttp.NewRemoteClient(unixSocketBaseURL, unixSocketHTTPClient(socketPath))
+
+	activateResponse := driver.Activate(logger)
+	if activateResponse.Err != "" {
+		return nil, errors.New(activateResponse.Err)
+	}
+
+	for _, implements := range activateResponse.Implements {
+		if implements == volumeDriverImplements {
+			return driver, nil
+		}
+	}
+
+	logger.Debug("plugin-does-not-implement-volumedriver", lager.Data{"implements": activateResponse.Implements})
+	return nil, nil
+}
+
+// unixSocketHTTPClient returns an http.Client that dials socketPath for
+// every request; the host portion of the request URL is ignored since only
+// a unix socket dialer is in play.
+func unixSocketHTTPClient(socketPath string) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package voldiscoverers_test
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/voldriver"
+	"code.cloudfoundry.org/voldriver/driverhttp"
+	"code.cloudfoundry.org/volman/voldiscoverers"
+	"code.cloudfoundry.org/volman/volmanfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DockerSocketDiscoverer", func() {
+	var (
+		socketDir string
+		listener  net.Listener
+		driver    *volmanfakes.FakeDriver
+	)
+
+	BeforeEach(func() {
+		var err error
+		socketDir, err = ioutil.TempDir("", "docker-plugins")
+		Expect(err).NotTo(HaveOccurred())
+
+		driver = &volmanfakes.FakeDriver{}
+		driver.ActivateReturns(voldriver.ActivateResponse{Implements: []string{"VolumeDriver"}})
+
+		handler, err := driverhttp.NewHandler(lagertest.NewTestLogger("socket-driver"), driver)
+		Expect(err).NotTo(HaveOccurred())
+
+		listener, err = net.Listen("unix", filepath.Join(socketDir, "some-plugin.sock"))
+		Expect(err).NotTo(HaveOccurred())
+		go http.Serve(listener, handler)
+	})
+
+	AfterEach(func() {
+		listener.Close()
+		os.RemoveAll(socketDir)
+	})
+
+	It("discovers a plugin advertised over a unix socket", func() {
+		discoverer := voldiscoverers.NewDockerSocketDiscoverer(lagertest.NewTestLogger("discoverer-test"), []string{socketDir})
+
+		drivers, err := discoverer.Discover(lagertest.NewTestLogger("discover"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drivers).To(HaveKey("some-plugin"))
+	})
+
+	It("skips plugins that don't implement VolumeDriver", func() {
+		driver.ActivateReturns(voldriver.ActivateResponse{Implements: []string{"NetworkDriver"}})
+
+		discoverer := voldiscoverers.NewDockerSocketDiscoverer(lagertest.NewTestLogger("discoverer-test"), []string{socketDir})
+		drivers, err := discoverer.Discover(lagertest.NewTestLogger("discover"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drivers).NotTo(HaveKey("some-plugin"))
+	})
+})
@@ -8,8 +8,9 @@ import (
 	"net/http"
 
 	cf_http_handlers "github.com/cloudfoundry-incubator/cf_http/handlers"
-	"github.com/cloudfoundry-incubator/volman/voldriver"
-	"github.com/pivotal-golang/lager"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/voldriver"
 	"github.com/tedsuo/rata"
 )
 
@@ -72,6 +73,32 @@ func NewHandler(logger lager.Logger, client voldriver.Driver) (http.Handler, err
 			cf_http_handlers.WriteJSONResponse(w, statusOK, getResponse)
 		}),
 
+		voldriver.ListRoute: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			logger := logger.Session("handle-list")
+			logger.Info("start")
+			defer logger.Info("end")
+
+			listResponse := client.List(logger)
+			if listResponse.Err != "" {
+				logger.Error("failed-listing-volumes", errors.New(listResponse.Err))
+				cf_http_handlers.WriteJSONResponse(w, statusInternalServerError, listResponse)
+				return
+			}
+
+			logger.Debug("list-response", lager.Data{"volumes": listResponse.Volumes})
+			cf_http_handlers.WriteJSONResponse(w, statusOK, listResponse)
+		}),
+
+		voldriver.CapabilitiesRoute: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			logger := logger.Session("handle-capabilities")
+			logger.Info("start")
+			defer logger.Info("end")
+
+			capabilitiesResponse := client.Capabilities(logger)
+			logger.Debug("capabilities-response", lager.Data{"capabilities": capabilitiesResponse.Capabilities})
+			cf_http_handlers.WriteJSONResponse(w, statusOK, capabilitiesResponse)
+		}),
+
 		voldriver.PathRoute: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			logger := logger.Session("handle-path")
 			logger.Info("start")
@@ -9,12 +9,12 @@ import (
 
 	"fmt"
 
-	"github.com/cloudfoundry-incubator/volman/voldriver"
-	"github.com/cloudfoundry-incubator/volman/voldriver/driverhttp"
-	"github.com/cloudfoundry-incubator/volman/volmanfakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/voldriver"
+	"code.cloudfoundry.org/voldriver/driverhttp"
+	"code.cloudfoundry.org/volman/volmanfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
-	"github.com/pivotal-golang/lager/lagertest"
 )
 
 var _ = Describe("Volman Driver Handlers", func() {
@@ -83,6 +83,62 @@ var _ = Describe("Volman Driver Handlers", func() {
 			Expect(mountResponse.Mountpoint).Should(Equal("dummy_path"))
 		})
 
+		It("should produce a handler with a list route", func() {
+			By("faking out the driver")
+			driver := &volmanfakes.FakeDriver{}
+			driver.ListReturns(voldriver.ListResponse{Volumes: []voldriver.VolumeInfo{{Name: "some-volume"}}})
+			handler, err := driverhttp.NewHandler(testLogger, driver)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("then fake serving the response using the handler")
+			route, found := voldriver.Routes.FindRouteByName(voldriver.ListRoute)
+			Expect(found).To(BeTrue())
+
+			path := fmt.Sprintf("http://0.0.0.0%s", route.Path)
+			httpRequest, err := http.NewRequest("POST", path, bytes.NewReader([]byte{}))
+			Expect(err).NotTo(HaveOccurred())
+
+			httpResponseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(httpResponseRecorder, httpRequest)
+
+			By("then deserialing the HTTP response")
+			listResponse := voldriver.ListResponse{}
+			body, err := ioutil.ReadAll(httpResponseRecorder.Body)
+			err = json.Unmarshal(body, &listResponse)
+
+			By("then expecting correct JSON conversion")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(listResponse.Volumes).Should(Equal([]voldriver.VolumeInfo{{Name: "some-volume"}}))
+		})
+
+		It("should produce a handler with a capabilities route", func() {
+			By("faking out the driver")
+			driver := &volmanfakes.FakeDriver{}
+			driver.CapabilitiesReturns(voldriver.CapabilitiesResponse{Capabilities: voldriver.CapabilityInfo{Scope: "global"}})
+			handler, err := driverhttp.NewHandler(testLogger, driver)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("then fake serving the response using the handler")
+			route, found := voldriver.Routes.FindRouteByName(voldriver.CapabilitiesRoute)
+			Expect(found).To(BeTrue())
+
+			path := fmt.Sprintf("http://0.0.0.0%s", route.Path)
+			httpRequest, err := http.NewRequest("POST", path, bytes.NewReader([]byte{}))
+			Expect(err).NotTo(HaveOccurred())
+
+			httpResponseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(httpResponseRecorder, httpRequest)
+
+			By("then deserialing the HTTP response")
+			capabilitiesResponse := voldriver.CapabilitiesResponse{}
+			body, err := ioutil.ReadAll(httpResponseRecorder.Body)
+			err = json.Unmarshal(body, &capabilitiesResponse)
+
+			By("then expecting correct JSON conversion")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(capabilitiesResponse.Capabilities.Scope).Should(Equal("global"))
+		})
+
 		It("should produce a handler with an unmount route", func() {
 			By("faking out the driver")
 			driver := &volmanfakes.FakeDriver{}
@@ -0,0 +1,185 @@
+package driverhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/voldriver"
+	"github.com/tedsuo/rata"
+)
+
+// dockerPluginContentType is the content type rexray/openstorage-style
+// plugins expect and respond with; without it some plugins fall back to a
+// stricter, older wire format that fails to deserialize against our types.
+const dockerPluginContentType = "application/vnd.docker.plugins.v1.2+json"
+
+// RemoteClient implements voldriver.Driver by calling a real Docker volume
+// plugin binary over HTTP. It understands the Docker daemon's back-compat
+// behavior for pre-1.2 plugins: when Get isn't implemented, it falls back to
+// Create (which is idempotent for an existing volume) and synthesizes a
+// GetResponse from the requested name.
+type RemoteClient struct {
+	reqGen     *rata.RequestGenerator
+	httpClient *http.Client
+}
+
+func NewRemoteClient(url string, httpClient *http.Client) *RemoteClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &RemoteClient{
+		reqGen:     rata.NewRequestGenerator(url, voldriver.Routes),
+		httpClient: httpClient,
+	}
+}
+
+func (r *RemoteClient) Activate(logger lager.Logger) voldriver.ActivateResponse {
+	logger = logger.Session("activate")
+
+	var response voldriver.ActivateResponse
+	if _, err := r.do(logger, voldriver.ActivateRoute, nil, &response); err != nil {
+		return voldriver.ActivateResponse{Err: err.Error()}
+	}
+	return response
+}
+
+func (r *RemoteClient) Get(logger lager.Logger, getRequest voldriver.GetRequest) voldriver.GetResponse {
+	logger = logger.Session("get")
+
+	var response voldriver.GetResponse
+	status, err := r.do(logger, voldriver.GetRoute, getRequest, &response)
+	if status != http.StatusNotFound {
+		if err != nil {
+			return voldriver.GetResponse{Err: err.Error()}
+		}
+		if !voldriver.IsNotImplemented(response.Err) {
+			return response
+		}
+	}
+
+	logger.Info("driver-does-not-implement-get-falling-back-to-create")
+	createResponse := r.Create(logger, voldriver.CreateRequest{Name: getRequest.Name})
+	if createResponse.Err != "" {
+		return voldriver.GetResponse{Err: createResponse.Err}
+	}
+	return voldriver.GetResponse{Volume: voldriver.VolumeInfo{Name: getRequest.Name}}
+}
+
+func (r *RemoteClient) List(logger lager.Logger) voldriver.ListResponse {
+	logger = logger.Session("list")
+
+	var response voldriver.ListResponse
+	if _, err := r.do(logger, voldriver.ListRoute, nil, &response); err != nil {
+		return voldriver.ListResponse{Err: err.Error()}
+	}
+	return response
+}
+
+func (r *RemoteClient) Capabilities(logger lager.Logger) voldriver.CapabilitiesResponse {
+	logger = logger.Session("capabilities")
+
+	var response voldriver.CapabilitiesResponse
+	if _, err := r.do(logger, voldriver.CapabilitiesRoute, nil, &response); err != nil {
+		// Drivers that predate Docker's Capabilities addition are assumed
+		// local-scoped, matching the Docker daemon's own default.
+		return voldriver.CapabilitiesResponse{Capabilities: voldriver.CapabilityInfo{Scope: "local"}}
+	}
+	return response
+}
+
+func (r *RemoteClient) Path(logger lager.Logger, pathRequest voldriver.PathRequest) voldriver.PathResponse {
+	logger = logger.Session("path")
+
+	var response voldriver.PathResponse
+	if _, err := r.do(logger, voldriver.PathRoute, pathRequest, &response); err != nil {
+		return voldriver.PathResponse{Err: err.Error()}
+	}
+	return response
+}
+
+func (r *RemoteClient) Create(logger lager.Logger, createRequest voldriver.CreateRequest) voldriver.ErrorResponse {
+	logger = logger.Session("create")
+
+	var response voldriver.ErrorResponse
+	if _, err := r.do(logger, voldriver.CreateRoute, createRequest, &response); err != nil {
+		return voldriver.ErrorResponse{Err: err.Error()}
+	}
+	return response
+}
+
+func (r *RemoteClient) Mount(logger lager.Logger, mountRequest voldriver.MountRequest) voldriver.MountResponse {
+	logger = logger.Session("mount")
+
+	var response voldriver.MountResponse
+	if _, err := r.do(logger, voldriver.MountRoute, mountRequest, &response); err != nil {
+		return voldriver.MountResponse{Err: err.Error()}
+	}
+	return response
+}
+
+func (r *RemoteClient) Unmount(logger lager.Logger, unmountRequest voldriver.UnmountRequest) voldriver.ErrorResponse {
+	logger = logger.Session("unmount")
+
+	var response voldriver.ErrorResponse
+	if _, err := r.do(logger, voldriver.UnmountRoute, unmountRequest, &response); err != nil {
+		return voldriver.ErrorResponse{Err: err.Error()}
+	}
+	return response
+}
+
+func (r *RemoteClient) Remove(logger lager.Logger, removeRequest voldriver.RemoveRequest) voldriver.ErrorResponse {
+	logger = logger.Session("remove")
+
+	var response voldriver.ErrorResponse
+	if _, err := r.do(logger, voldriver.RemoveRoute, removeRequest, &response); err != nil {
+		return voldriver.ErrorResponse{Err: err.Error()}
+	}
+	return response
+}
+
+// do performs the request and returns the HTTP status code alongside any
+// transport or unmarshalling error, so callers like Get can distinguish a
+// real 404 (driver doesn't implement this verb) from other failures.
+func (r *RemoteClient) do(logger lager.Logger, routeName string, requestBody interface{}, responseBody interface{}) (int, error) {
+	body := bytes.NewReader([]byte{})
+	if requestBody != nil {
+		marshalled, err := json.Marshal(requestBody)
+		if err != nil {
+			logger.Error("failed-marshalling-request", err)
+			return 0, err
+		}
+		body = bytes.NewReader(marshalled)
+	}
+
+	httpRequest, err := r.reqGen.CreateRequest(routeName, nil, body)
+	if err != nil {
+		logger.Error("failed-creating-request", err)
+		return 0, err
+	}
+	httpRequest.Header.Set("Content-Type", dockerPluginContentType)
+	httpRequest.Header.Set("Accept", dockerPluginContentType)
+
+	httpResponse, err := r.httpClient.Do(httpRequest)
+	if err != nil {
+		logger.Error("failed-performing-request", err)
+		return 0, err
+	}
+	defer httpResponse.Body.Close()
+
+	responseBytes, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		logger.Error("failed-reading-response-body", err)
+		return httpResponse.StatusCode, err
+	}
+
+	if err := json.Unmarshal(responseBytes, responseBody); err != nil {
+		logger.Error("failed-unmarshalling-response-body", err)
+		return httpResponse.StatusCode, err
+	}
+
+	return httpResponse.StatusCode, nil
+}
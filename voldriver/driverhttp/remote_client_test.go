@@ -0,0 +1,93 @@
+package driverhttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/voldriver"
+	"code.cloudfoundry.org/voldriver/driverhttp"
+	"code.cloudfoundry.org/volman/volmanfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RemoteClient", func() {
+	var testLogger = lagertest.NewTestLogger("RemoteClientTest")
+
+	Describe("Get", func() {
+		var (
+			driver *volmanfakes.FakeDriver
+			server *httptest.Server
+			client *driverhttp.RemoteClient
+		)
+
+		BeforeEach(func() {
+			driver = &volmanfakes.FakeDriver{}
+			handler, err := driverhttp.NewHandler(testLogger, driver)
+			Expect(err).NotTo(HaveOccurred())
+
+			server = httptest.NewServer(handler)
+			client = driverhttp.NewRemoteClient(server.URL, nil)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("returns the driver's response when Get succeeds", func() {
+			driver.GetReturns(voldriver.GetResponse{Volume: voldriver.VolumeInfo{Name: "some-volume"}})
+
+			response := client.Get(testLogger, voldriver.GetRequest{Name: "some-volume"})
+			Expect(response.Err).To(BeEmpty())
+			Expect(response.Volume.Name).To(Equal("some-volume"))
+			Expect(driver.CreateCallCount()).To(Equal(0))
+		})
+
+		It("falls back to Create when the driver reports Get as not implemented", func() {
+			driver.GetReturns(voldriver.GetResponse{Err: "not implemented"})
+			driver.CreateReturns(voldriver.ErrorResponse{})
+
+			response := client.Get(testLogger, voldriver.GetRequest{Name: "some-volume"})
+			Expect(response.Err).To(BeEmpty())
+			Expect(response.Volume.Name).To(Equal("some-volume"))
+			Expect(driver.CreateCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("Get against a driver returning a malformed response", func() {
+		var (
+			getCalls, createCalls int
+			server                *httptest.Server
+			client                *driverhttp.RemoteClient
+		)
+
+		BeforeEach(func() {
+			getCalls, createCalls = 0, 0
+			mux := http.NewServeMux()
+			mux.HandleFunc("/VolumeDriver.Get", func(w http.ResponseWriter, req *http.Request) {
+				getCalls++
+				w.Write([]byte("not-valid-json"))
+			})
+			mux.HandleFunc("/VolumeDriver.Create", func(w http.ResponseWriter, req *http.Request) {
+				createCalls++
+				w.Write([]byte("{}"))
+			})
+
+			server = httptest.NewServer(mux)
+			client = driverhttp.NewRemoteClient(server.URL, nil)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("surfaces the unmarshalling error instead of silently falling back to Create", func() {
+			response := client.Get(testLogger, voldriver.GetRequest{Name: "some-volume"})
+
+			Expect(response.Err).NotTo(BeEmpty())
+			Expect(getCalls).To(Equal(1))
+			Expect(createCalls).To(Equal(0))
+		})
+	})
+})
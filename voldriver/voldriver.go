@@ -0,0 +1,139 @@
+package voldriver
+
+import (
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/tedsuo/rata"
+)
+
+const (
+	ActivateRoute     = "activate"
+	GetRoute          = "get"
+	ListRoute         = "list"
+	CapabilitiesRoute = "capabilities"
+	PathRoute         = "path"
+	CreateRoute       = "create"
+	MountRoute        = "mount"
+	UnmountRoute      = "unmount"
+	RemoveRoute       = "remove"
+)
+
+var Routes = rata.Routes{
+	{Path: "/Plugin.Activate", Method: "POST", Name: ActivateRoute},
+	{Path: "/VolumeDriver.Get", Method: "POST", Name: GetRoute},
+	{Path: "/VolumeDriver.List", Method: "POST", Name: ListRoute},
+	{Path: "/VolumeDriver.Capabilities", Method: "POST", Name: CapabilitiesRoute},
+	{Path: "/VolumeDriver.Path", Method: "POST", Name: PathRoute},
+	{Path: "/VolumeDriver.Create", Method: "POST", Name: CreateRoute},
+	{Path: "/VolumeDriver.Mount", Method: "POST", Name: MountRoute},
+	{Path: "/VolumeDriver.Unmount", Method: "POST", Name: UnmountRoute},
+	{Path: "/VolumeDriver.Remove", Method: "POST", Name: RemoveRoute},
+}
+
+// Driver is implemented by every Docker volume plugin binary that volman talks to
+// over HTTP. It mirrors the Docker Volume Plugin protocol.
+type Driver interface {
+	Activate(logger lager.Logger) ActivateResponse
+	Get(logger lager.Logger, getRequest GetRequest) GetResponse
+	List(logger lager.Logger) ListResponse
+	Capabilities(logger lager.Logger) CapabilitiesResponse
+	Path(logger lager.Logger, pathRequest PathRequest) PathResponse
+	Create(logger lager.Logger, createRequest CreateRequest) ErrorResponse
+	Mount(logger lager.Logger, mountRequest MountRequest) MountResponse
+	Unmount(logger lager.Logger, unmountRequest UnmountRequest) ErrorResponse
+	Remove(logger lager.Logger, removeRequest RemoveRequest) ErrorResponse
+}
+
+type SafeError struct {
+	SafeDescription string
+}
+
+func (s SafeError) Error() string {
+	return s.SafeDescription
+}
+
+type VolumeInfo struct {
+	Name       string
+	Mountpoint string
+}
+
+type ActivateResponse struct {
+	Implements []string
+	Err        string `json:",omitempty"`
+}
+
+type GetRequest struct {
+	Name string
+}
+
+type GetResponse struct {
+	Volume VolumeInfo
+	Err    string `json:",omitempty"`
+}
+
+// ListResponse is returned from VolumeDriver.List and enumerates every volume
+// the driver currently knows about, regardless of whether it is mounted.
+type ListResponse struct {
+	Volumes []VolumeInfo
+	Err     string `json:",omitempty"`
+}
+
+// CapabilitiesResponse is returned from VolumeDriver.Capabilities. Scope is
+// either "global" (the volume is visible cluster-wide, e.g. backed by shared
+// storage) or "local" (the volume only exists on the host the driver runs on).
+type CapabilitiesResponse struct {
+	Capabilities CapabilityInfo
+	Err          string `json:",omitempty"`
+}
+
+type CapabilityInfo struct {
+	Scope string
+}
+
+type PathRequest struct {
+	Name string
+}
+
+type PathResponse struct {
+	Mountpoint string
+	Err        string `json:",omitempty"`
+}
+
+type CreateRequest struct {
+	Name string
+	Opts map[string]interface{}
+}
+
+type MountRequest struct {
+	Name string
+}
+
+type MountResponse struct {
+	Mountpoint string
+	Err        string `json:",omitempty"`
+}
+
+type UnmountRequest struct {
+	Name string
+}
+
+type RemoveRequest struct {
+	Name string
+}
+
+type ErrorResponse struct {
+	Err string `json:",omitempty"`
+}
+
+// notImplementedMarker is how pre-1.2 Docker volume plugins spell "I don't
+// know this verb" in an Err field, for transports that can't surface a real
+// 404 back to us.
+const notImplementedMarker = "not implemented"
+
+// IsNotImplemented reports whether errString is a driver's way of saying it
+// doesn't implement the verb that was just called, so callers can fall back
+// to an equivalent older verb instead of treating it as a real failure.
+func IsNotImplemented(errString string) bool {
+	return errString != "" && strings.Contains(strings.ToLower(errString), notImplementedMarker)
+}
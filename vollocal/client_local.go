@@ -1,7 +1,9 @@
 package vollocal
 
 import (
+	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/tedsuo/ifrit"
@@ -18,28 +20,45 @@ import (
 )
 
 const (
-	volmanMountErrorsCounter   = "VolmanMountErrors"
-	volmanMountDuration        = "VolmanMountDuration"
-	volmanUnmountErrorsCounter = "VolmanUnmountErrors"
-	volmanUnmountDuration      = "VolmanUnmountDuration"
+	volmanMountErrorsCounter       = "VolmanMountErrors"
+	volmanMountDuration            = "VolmanMountDuration"
+	volmanUnmountErrorsCounter     = "VolmanUnmountErrors"
+	volmanUnmountDuration          = "VolmanUnmountDuration"
+	volmanMountRefCountMetric      = "VolmanMountRefCount"
+	volmanMountRetriesCounter      = "VolmanMountRetries"
+	volmanMountAttemptsHistogram   = "VolmanMountAttempts"
+	volmanUnmountRetriesCounter    = "VolmanUnmountRetries"
+	volmanUnmountAttemptsHistogram = "VolmanUnmountAttempts"
+
+	mountAttemptKind   = "mount"
+	unmountAttemptKind = "unmount"
 )
 
 var (
-	pluginMountDurations   = map[string]string{}
-	pluginUnmountDurations = map[string]string{}
+	pluginMountDurationsMutex   sync.Mutex
+	pluginMountDurations        = map[string]string{}
+	pluginUnmountDurationsMutex sync.Mutex
+	pluginUnmountDurations      = map[string]string{}
+
+	pluginRetriesCountersMutex sync.Mutex
+	pluginRetriesCounters      = map[string]string{}
 )
 
 type DriverConfig struct {
-	DriverPaths     []string
-	CSIPaths        []string
-	SyncInterval    time.Duration
-	CSIMountRootDir string
-	MapfsPath       string
+	DriverPaths            []string
+	CSIPaths               []string
+	SyncInterval           time.Duration
+	CSIMountRootDir        string
+	MapfsPath              string
+	MountStateDir          string
+	DockerPluginSocketDirs []string
+	RetryPolicy            RetryPolicy
 }
 
 func NewDriverConfig() DriverConfig {
 	return DriverConfig{
 		SyncInterval: time.Second * 30,
+		RetryPolicy:  NewRetryPolicy(),
 	}
 }
 
@@ -47,28 +66,38 @@ type localClient struct {
 	pluginRegistry volman.PluginRegistry
 	metronClient   loggingclient.IngressClient
 	clock          clock.Clock
+	refCounter     *mountRefCounter
+	retryPolicy    RetryPolicy
 }
 
 func NewServer(logger lager.Logger, metronClient loggingclient.IngressClient, config DriverConfig) (volman.Manager, ifrit.Runner) {
 	clock := clock.NewClock()
 	registry := NewPluginRegistry()
+	refCounter := newMountRefCounter(config.MountStateDir)
 
 	dockerDiscoverer := voldiscoverers.NewDockerDriverDiscoverer(logger, registry, config.DriverPaths)
 	csiDiscoverer := voldiscoverers.NewCsiPluginDiscoverer(logger, registry, config.CSIPaths, config.CSIMountRootDir, config.MapfsPath)
+	dockerSocketDiscoverer := voldiscoverers.NewDockerSocketDiscoverer(logger, config.DockerPluginSocketDirs)
 
-	syncer := NewSyncer(logger, registry, []volman.Discoverer{dockerDiscoverer, csiDiscoverer}, config.SyncInterval, clock)
-	purger := NewMountPurger(logger, registry)
+	syncer := NewSyncer(logger, registry, []volman.Discoverer{dockerDiscoverer, csiDiscoverer, dockerSocketDiscoverer}, config.SyncInterval, clock)
+	purger := NewMountPurger(logger, registry, refCounter)
 
 	grouper := grouper.NewOrdered(os.Kill, grouper.Members{grouper.Member{Name: "volman-syncer", Runner: syncer.Runner()}, grouper.Member{Name: "volman-purger", Runner: purger.Runner()}})
 
-	return NewLocalClient(logger, registry, metronClient, clock), grouper
+	return newLocalClient(registry, metronClient, clock, refCounter, config.RetryPolicy), grouper
 }
 
 func NewLocalClient(logger lager.Logger, registry volman.PluginRegistry, metronClient loggingclient.IngressClient, clock clock.Clock) volman.Manager {
+	return newLocalClient(registry, metronClient, clock, newMountRefCounter(""), NewRetryPolicy())
+}
+
+func newLocalClient(registry volman.PluginRegistry, metronClient loggingclient.IngressClient, clock clock.Clock, refCounter *mountRefCounter, retryPolicy RetryPolicy) volman.Manager {
 	return &localClient{
 		pluginRegistry: registry,
 		metronClient:   metronClient,
 		clock:          clock,
+		refCounter:     refCounter,
+		retryPolicy:    retryPolicy,
 	}
 }
 
@@ -88,7 +117,53 @@ func (client *localClient) ListDrivers(logger lager.Logger) (volman.ListDriversR
 	return volman.ListDriversResponse{Drivers: infoResponses}, nil
 }
 
+func (client *localClient) ListVolumes(logger lager.Logger, pluginId string) (volman.ListResponse, error) {
+	logger = logger.Session("list-volumes")
+	logger.Info("start")
+	defer logger.Info("end")
+
+	plugin, found := client.pluginRegistry.Plugin(pluginId)
+	if !found {
+		err := errors.New("Plugin '" + pluginId + "' not found in list of known plugins")
+		logger.Error("list-volumes-plugin-lookup-error", err)
+		return volman.ListResponse{}, err
+	}
+
+	listResponse, err := plugin.List(logger)
+	if err != nil {
+		logger.Error("list-volumes-failed", err, lager.Data{"pluginId": pluginId})
+		return volman.ListResponse{}, err
+	}
+
+	return listResponse, nil
+}
+
+func (client *localClient) GetCapabilities(logger lager.Logger, pluginId string) (volman.CapabilitiesResponse, error) {
+	logger = logger.Session("get-capabilities")
+	logger.Info("start")
+	defer logger.Info("end")
+
+	plugin, found := client.pluginRegistry.Plugin(pluginId)
+	if !found {
+		err := errors.New("Plugin '" + pluginId + "' not found in list of known plugins")
+		logger.Error("get-capabilities-plugin-lookup-error", err)
+		return volman.CapabilitiesResponse{}, err
+	}
+
+	capabilitiesResponse, err := plugin.Capabilities(logger)
+	if err != nil {
+		logger.Error("get-capabilities-failed", err, lager.Data{"pluginId": pluginId})
+		return volman.CapabilitiesResponse{}, err
+	}
+
+	return capabilitiesResponse, nil
+}
+
 func (client *localClient) Mount(logger lager.Logger, pluginId string, volumeId string, config map[string]interface{}) (volman.MountResponse, error) {
+	return client.MountWithContext(context.Background(), logger, pluginId, volumeId, config)
+}
+
+func (client *localClient) MountWithContext(ctx context.Context, logger lager.Logger, pluginId string, volumeId string, config map[string]interface{}) (volman.MountResponse, error) {
 	logger = logger.Session("mount")
 	logger.Info("start")
 	defer logger.Info("end")
@@ -109,7 +184,21 @@ func (client *localClient) Mount(logger lager.Logger, pluginId string, volumeId
 		return volman.MountResponse{}, err
 	}
 
-	mountResponse, err := plugin.Mount(logger, volumeId, config)
+	if client.retryPolicy.CallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, client.retryPolicy.CallTimeout)
+		defer cancel()
+	}
+
+	var mountResponse volman.MountResponse
+	attempts, err := withRetry(ctx, client.clock, client.retryPolicy, func() error {
+		var mountErr error
+		mountResponse, mountErr = client.refCounter.Increment(pluginId, volumeId, func() (volman.MountResponse, error) {
+			return plugin.Mount(logger, volumeId, config)
+		})
+		return mountErr
+	})
+	client.sendAttemptMetrics(logger, mountAttemptKind, pluginId, attempts)
 
 	if err != nil {
 		client.metronClient.IncrementCounter(volmanMountErrorsCounter)
@@ -119,20 +208,72 @@ func (client *localClient) Mount(logger lager.Logger, pluginId string, volumeId
 		return volman.MountResponse{}, err
 	}
 
+	client.sendMountRefCountMetric(logger, pluginId, client.refCounter.Count(pluginId, volumeId))
+
 	return mountResponse, nil
 }
 
+// sendAttemptMetrics records how many attempts a Mount or Unmount call took
+// against a specific plugin: one attempt-count histogram sample, plus one
+// retries-counter increment per attempt beyond the first.
+func (client *localClient) sendAttemptMetrics(logger lager.Logger, kind string, pluginId string, attempts int) {
+	attemptsHistogram := volmanMountAttemptsHistogram
+	retriesCounter := volmanMountRetriesCounter
+	if kind == unmountAttemptKind {
+		attemptsHistogram = volmanUnmountAttemptsHistogram
+		retriesCounter = volmanUnmountRetriesCounter
+	}
+
+	if err := client.metronClient.SendMetric(attemptsHistogram, attempts); err != nil {
+		logger.Error("failed-to-send-volman-attempts-metric", err)
+	}
+
+	if attempts <= 1 {
+		return
+	}
+
+	m := pluginMetricName(&pluginRetriesCountersMutex, pluginRetriesCounters, retriesCounter, pluginId)
+	for i := 0; i < attempts-1; i++ {
+		client.metronClient.IncrementCounter(m)
+	}
+}
+
+// pluginMetricName returns the cached per-plugin metric name for prefix,
+// computing and caching it on first use. The cache is shared across metric
+// kinds, so callers pass a mutex/map pair scoped to their own metric family.
+func pluginMetricName(mutex *sync.Mutex, cache map[string]string, prefix string, pluginId string) string {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	key := prefix + "/" + pluginId
+	name, ok := cache[key]
+	if !ok {
+		name = prefix + "For" + pluginId
+		cache[key] = name
+	}
+	return name
+}
+
+func (client *localClient) sendMountRefCountMetric(logger lager.Logger, pluginId string, count int) {
+	err := client.metronClient.SendMetric(volmanMountRefCountMetric, count)
+	if err != nil {
+		logger.Error("failed-to-send-volman-mount-refcount-metric", err)
+	}
+}
+
+// MountRefCounts exposes the current (pluginId, volumeId) refcount table for
+// debugging, e.g. via an admin endpoint.
+func (client *localClient) MountRefCounts() map[string]int {
+	return client.refCounter.Counts()
+}
+
 func sendMountDurationMetrics(logger lager.Logger, metronClient loggingclient.IngressClient, duration time.Duration, pluginId string) {
 	err := metronClient.SendDuration(volmanMountDuration, duration)
 	if err != nil {
 		logger.Error("failed-to-send-volman-mount-duration-metric", err)
 	}
 
-	m, ok := pluginMountDurations[pluginId]
-	if !ok {
-		m = "VolmanMountDurationFor" + pluginId
-		pluginMountDurations[pluginId] = m
-	}
+	m := pluginMetricName(&pluginMountDurationsMutex, pluginMountDurations, "VolmanMountDuration", pluginId)
 	err = metronClient.SendDuration(m, duration)
 	if err != nil {
 		logger.Error("failed-to-send-volman-mount-duration-metric", err)
@@ -145,11 +286,7 @@ func sendUnmountDurationMetrics(logger lager.Logger, metronClient loggingclient.
 		logger.Error("failed-to-send-volman-unmount-duration-metric", err)
 	}
 
-	m, ok := pluginUnmountDurations[pluginId]
-	if !ok {
-		m = "VolmanUnmountDurationFor" + pluginId
-		pluginUnmountDurations[pluginId] = m
-	}
+	m := pluginMetricName(&pluginUnmountDurationsMutex, pluginUnmountDurations, "VolmanUnmountDuration", pluginId)
 	err = metronClient.SendDuration(m, duration)
 	if err != nil {
 		logger.Error("failed-to-send-volman-unmount-duration-metric", err)
@@ -157,6 +294,10 @@ func sendUnmountDurationMetrics(logger lager.Logger, metronClient loggingclient.
 }
 
 func (client *localClient) Unmount(logger lager.Logger, pluginId string, volumeId string) error {
+	return client.UnmountWithContext(context.Background(), logger, pluginId, volumeId)
+}
+
+func (client *localClient) UnmountWithContext(ctx context.Context, logger lager.Logger, pluginId string, volumeId string) error {
 	logger = logger.Session("unmount")
 	logger.Info("start")
 	defer logger.Info("end")
@@ -176,7 +317,19 @@ func (client *localClient) Unmount(logger lager.Logger, pluginId string, volumeI
 		return err
 	}
 
-	err := plugin.Unmount(logger, volumeId)
+	if client.retryPolicy.CallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, client.retryPolicy.CallTimeout)
+		defer cancel()
+	}
+
+	attempts, err := withRetry(ctx, client.clock, client.retryPolicy, func() error {
+		return client.refCounter.Decrement(pluginId, volumeId, func() error {
+			return plugin.Unmount(logger, volumeId)
+		})
+	})
+	client.sendAttemptMetrics(logger, unmountAttemptKind, pluginId, attempts)
+
 	if err != nil {
 		client.metronClient.IncrementCounter(volmanUnmountErrorsCounter)
 		logger.Error("unmount-failed", err)
@@ -187,5 +340,7 @@ func (client *localClient) Unmount(logger lager.Logger, pluginId string, volumeI
 		return err
 	}
 
+	client.sendMountRefCountMetric(logger, pluginId, client.refCounter.Count(pluginId, volumeId))
+
 	return nil
 }
@@ -0,0 +1,100 @@
+package vollocal
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/volman"
+)
+
+// MountPurger reconciles volman's view of what is mounted against what the
+// plugins themselves report, once at startup.
+type MountPurger struct {
+	logger     lager.Logger
+	registry   volman.PluginRegistry
+	refCounter *mountRefCounter
+}
+
+func NewMountPurger(logger lager.Logger, registry volman.PluginRegistry, refCounter *mountRefCounter) *MountPurger {
+	return &MountPurger{
+		logger:     logger.Session("mount-purger"),
+		registry:   registry,
+		refCounter: refCounter,
+	}
+}
+
+func (p *MountPurger) Runner() *mountPurgerRunner {
+	return &mountPurgerRunner{purger: p}
+}
+
+type mountPurgerRunner struct {
+	purger *MountPurger
+}
+
+func (r *mountPurgerRunner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	r.purger.Purge()
+
+	close(ready)
+
+	<-signals
+	return nil
+}
+
+// legacyVerifier is implemented by plugins (driverPlugin) whose List call
+// can't be trusted as an independent source of truth -- its IsLegacyList
+// flag means List is itself synthesized from the refcounter. Purge uses
+// VerifyMounted instead, which asks the driver directly about each volume.
+type legacyVerifier interface {
+	IsLegacyList() bool
+	VerifyMounted(logger lager.Logger, volumeId string) bool
+}
+
+// Purge asks every known plugin what it thinks is mounted and drops any
+// refcount entries that don't match, so a volman restart doesn't get stuck
+// believing a volume is still in use when the plugin disagrees.
+func (p *MountPurger) Purge() {
+	logger := p.logger.Session("purge")
+	logger.Info("start")
+	defer logger.Info("end")
+
+	if p.refCounter == nil {
+		return
+	}
+
+	for pluginId, plugin := range p.registry.Plugins() {
+		knownVolumes := p.knownVolumes(logger, pluginId, plugin)
+		if knownVolumes == nil {
+			continue
+		}
+
+		p.refCounter.Reconcile(logger, pluginId, knownVolumes)
+	}
+}
+
+// knownVolumes returns the volumes plugin reports as mounted, or nil if
+// that can't be determined. For a legacyList plugin, List would just echo
+// the refcounter back at us, so each refcounted volume is instead verified
+// directly against the driver.
+func (p *MountPurger) knownVolumes(logger lager.Logger, pluginId string, plugin volman.Plugin) map[string]struct{} {
+	if verifier, ok := plugin.(legacyVerifier); ok && verifier.IsLegacyList() {
+		knownVolumes := map[string]struct{}{}
+		for _, volume := range p.refCounter.KnownVolumes(pluginId) {
+			if verifier.VerifyMounted(logger, volume.Name) {
+				knownVolumes[volume.Name] = struct{}{}
+			}
+		}
+		return knownVolumes
+	}
+
+	listResponse, err := plugin.List(logger)
+	if err != nil {
+		logger.Error("failed-listing-plugin-volumes", err, lager.Data{"pluginId": pluginId})
+		return nil
+	}
+
+	knownVolumes := map[string]struct{}{}
+	for _, volume := range listResponse.Volumes {
+		knownVolumes[volume.Name] = struct{}{}
+	}
+	return knownVolumes
+}
@@ -0,0 +1,66 @@
+package vollocal
+
+import (
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/voldriver"
+	"code.cloudfoundry.org/volman"
+	"code.cloudfoundry.org/volman/volmanfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MountPurger", func() {
+	var (
+		logger     = lagertest.NewTestLogger("mount-purger-test")
+		registry   volman.PluginRegistry
+		refCounter *mountRefCounter
+		purger     *MountPurger
+	)
+
+	BeforeEach(func() {
+		registry = NewPluginRegistry()
+		refCounter = newMountRefCounter("")
+		purger = NewMountPurger(logger, registry, refCounter)
+	})
+
+	Context("with a modern driver that implements List", func() {
+		It("drops refcounts for volumes the driver no longer reports mounted", func() {
+			driver := &volmanfakes.FakeDriver{}
+			driver.ListReturns(voldriver.ListResponse{Volumes: []voldriver.VolumeInfo{{Name: "volume-1"}}})
+			plugin := NewDriverPlugin(logger, "some-plugin", driver, refCounter)
+			registry.Set(map[string]volman.Plugin{"some-plugin": plugin})
+
+			refCounter.Increment("some-plugin", "volume-1", func() (volman.MountResponse, error) { return volman.MountResponse{}, nil })
+			refCounter.Increment("some-plugin", "volume-2", func() (volman.MountResponse, error) { return volman.MountResponse{}, nil })
+
+			purger.Purge()
+
+			Expect(refCounter.Count("some-plugin", "volume-1")).To(Equal(1))
+			Expect(refCounter.Count("some-plugin", "volume-2")).To(Equal(0))
+		})
+	})
+
+	Context("with a legacy driver that doesn't implement List", func() {
+		It("verifies each refcounted volume directly against the driver instead of trusting List", func() {
+			driver := &volmanfakes.FakeDriver{}
+			driver.ListReturns(voldriver.ListResponse{Err: "not implemented"})
+			driver.GetStub = func(logger lager.Logger, req voldriver.GetRequest) voldriver.GetResponse {
+				if req.Name == "volume-1" {
+					return voldriver.GetResponse{Volume: voldriver.VolumeInfo{Name: "volume-1"}}
+				}
+				return voldriver.GetResponse{Err: "not found"}
+			}
+			plugin := NewDriverPlugin(logger, "legacy-plugin", driver, refCounter)
+			registry.Set(map[string]volman.Plugin{"legacy-plugin": plugin})
+
+			refCounter.Increment("legacy-plugin", "volume-1", func() (volman.MountResponse, error) { return volman.MountResponse{}, nil })
+			refCounter.Increment("legacy-plugin", "volume-2", func() (volman.MountResponse, error) { return volman.MountResponse{}, nil })
+
+			purger.Purge()
+
+			Expect(refCounter.Count("legacy-plugin", "volume-1")).To(Equal(1))
+			Expect(refCounter.Count("legacy-plugin", "volume-2")).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,242 @@
+package vollocal
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/volman"
+)
+
+const mountRefCounterStateFile = "mount-refcounts.json"
+
+type mountRefKey struct {
+	PluginId string
+	VolumeId string
+}
+
+type mountRefEntry struct {
+	Count         int
+	MountResponse volman.MountResponse
+}
+
+// mountRefCounter tracks how many containers currently have a given
+// (pluginId, volumeId) mounted, so that localClient only asks the plugin to
+// actually mount or unmount on the 0->1 and 1->0 transitions. Containers
+// sharing a volume would otherwise race to unmount it out from under each
+// other.
+type mountRefCounter struct {
+	mutex    sync.Mutex
+	stateDir string
+	counts   map[mountRefKey]*mountRefEntry
+	keyLocks map[mountRefKey]*sync.Mutex
+}
+
+func newMountRefCounter(stateDir string) *mountRefCounter {
+	r := &mountRefCounter{
+		stateDir: stateDir,
+		counts:   map[mountRefKey]*mountRefEntry{},
+		keyLocks: map[mountRefKey]*sync.Mutex{},
+	}
+	r.load()
+	return r
+}
+
+// lockKey serializes Increment/Decrement calls for a single (pluginId,
+// volumeId), without forcing unrelated keys to wait behind it. Callers must
+// unlock the returned mutex.
+func (r *mountRefCounter) lockKey(key mountRefKey) *sync.Mutex {
+	r.mutex.Lock()
+	keyLock, found := r.keyLocks[key]
+	if !found {
+		keyLock = &sync.Mutex{}
+		r.keyLocks[key] = keyLock
+	}
+	r.mutex.Unlock()
+
+	keyLock.Lock()
+	return keyLock
+}
+
+// Increment returns the MountResponse to use for this mount, calling onMount
+// only on a 0->1 transition and otherwise returning the cached response
+// from that first mount. The per-key lock is held for the whole call, including
+// onMount, but r.mutex is only held around the refcount bookkeeping so a
+// slow or retrying plugin call for one (pluginId, volumeId) doesn't block
+// Mount/Unmount for any other one.
+func (r *mountRefCounter) Increment(pluginId, volumeId string, onMount func() (volman.MountResponse, error)) (volman.MountResponse, error) {
+	key := mountRefKey{PluginId: pluginId, VolumeId: volumeId}
+	keyLock := r.lockKey(key)
+	defer keyLock.Unlock()
+
+	r.mutex.Lock()
+	entry, found := r.counts[key]
+	if found {
+		entry.Count++
+		r.persist()
+	}
+	r.mutex.Unlock()
+
+	if found {
+		return entry.MountResponse, nil
+	}
+
+	mountResponse, err := onMount()
+	if err != nil {
+		return volman.MountResponse{}, err
+	}
+
+	r.mutex.Lock()
+	r.counts[key] = &mountRefEntry{Count: 1, MountResponse: mountResponse}
+	r.persist()
+	r.mutex.Unlock()
+
+	return mountResponse, nil
+}
+
+// Decrement calls onUnmount on the 1->0 transition, and also when no
+// refcount is recorded for the key; otherwise it only decrements. See
+// Increment for the locking discipline.
+func (r *mountRefCounter) Decrement(pluginId, volumeId string, onUnmount func() error) error {
+	key := mountRefKey{PluginId: pluginId, VolumeId: volumeId}
+	keyLock := r.lockKey(key)
+	defer keyLock.Unlock()
+
+	r.mutex.Lock()
+	entry, found := r.counts[key]
+	lastRef := !found || entry.Count <= 1
+	if lastRef {
+		delete(r.counts, key)
+	} else {
+		entry.Count--
+	}
+	r.persist()
+	r.mutex.Unlock()
+
+	if lastRef {
+		return onUnmount()
+	}
+	return nil
+}
+
+func (r *mountRefCounter) Count(pluginId, volumeId string) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry, found := r.counts[mountRefKey{PluginId: pluginId, VolumeId: volumeId}]
+	if !found {
+		return 0
+	}
+	return entry.Count
+}
+
+// KnownVolumes returns the volumes this plugin has outstanding mounts for,
+// according to the refcount table. It's used to synthesize a List response
+// for drivers that don't implement VolumeDriver.List themselves.
+func (r *mountRefCounter) KnownVolumes(pluginId string) []volman.VolumeInfo {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var volumes []volman.VolumeInfo
+	for key, entry := range r.counts {
+		if key.PluginId != pluginId {
+			continue
+		}
+		volumes = append(volumes, volman.VolumeInfo{Name: key.VolumeId, Mountpoint: entry.MountResponse.Path})
+	}
+	return volumes
+}
+
+// Counts returns a snapshot of the refcount table, keyed "pluginId/volumeId",
+// for debugging and metrics.
+func (r *mountRefCounter) Counts() map[string]int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	counts := map[string]int{}
+	for key, entry := range r.counts {
+		counts[key.PluginId+"/"+key.VolumeId] = entry.Count
+	}
+	return counts
+}
+
+// Reconcile drops any refcount entries for volumes the plugin no longer
+// reports as mounted, so a volman restart can't leave a stale refcount
+// around forever if the plugin itself lost track of the mount.
+func (r *mountRefCounter) Reconcile(logger lager.Logger, pluginId string, knownVolumes map[string]struct{}) {
+	logger = logger.Session("reconcile-refcounts", lager.Data{"pluginId": pluginId})
+	logger.Info("start")
+	defer logger.Info("end")
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for key := range r.counts {
+		if key.PluginId != pluginId {
+			continue
+		}
+		if _, ok := knownVolumes[key.VolumeId]; !ok {
+			logger.Info("dropping-stale-refcount", lager.Data{"volumeId": key.VolumeId})
+			delete(r.counts, key)
+		}
+	}
+	r.persist()
+}
+
+func (r *mountRefCounter) load() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	contents, err := ioutil.ReadFile(r.path())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var persisted []struct {
+		Key   mountRefKey
+		Entry mountRefEntry
+	}
+	if err := json.Unmarshal(contents, &persisted); err != nil {
+		return err
+	}
+
+	for _, p := range persisted {
+		r.counts[p.Key] = &mountRefEntry{Count: p.Entry.Count, MountResponse: p.Entry.MountResponse}
+	}
+	return nil
+}
+
+// persist must be called with r.mutex held.
+func (r *mountRefCounter) persist() {
+	if r.stateDir == "" {
+		return
+	}
+
+	type persistedEntry struct {
+		Key   mountRefKey
+		Entry mountRefEntry
+	}
+
+	persisted := make([]persistedEntry, 0, len(r.counts))
+	for key, entry := range r.counts {
+		persisted = append(persisted, persistedEntry{Key: key, Entry: *entry})
+	}
+
+	contents, err := json.Marshal(persisted)
+	if err != nil {
+		return
+	}
+
+	os.MkdirAll(r.stateDir, 0755)
+	ioutil.WriteFile(r.path(), contents, 0644)
+}
+
+func (r *mountRefCounter) path() string {
+	return filepath.Join(r.stateDir, mountRefCounterStateFile)
+}
@@ -0,0 +1,114 @@
+package vollocal
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/volman"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("mountRefCounter", func() {
+	var (
+		refCounter *mountRefCounter
+		stateDir   string
+	)
+
+	BeforeEach(func() {
+		var err error
+		stateDir, err = ioutil.TempDir("", "mount-refcounter")
+		Expect(err).NotTo(HaveOccurred())
+		refCounter = newMountRefCounter(stateDir)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(stateDir)
+	})
+
+	It("only calls onMount on the first Increment for a key", func() {
+		calls := 0
+		onMount := func() (volman.MountResponse, error) {
+			calls++
+			return volman.MountResponse{Path: "/some/path"}, nil
+		}
+
+		response, err := refCounter.Increment("plugin-a", "volume-1", onMount)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Path).To(Equal("/some/path"))
+
+		response, err = refCounter.Increment("plugin-a", "volume-1", onMount)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Path).To(Equal("/some/path"))
+
+		Expect(calls).To(Equal(1))
+		Expect(refCounter.Count("plugin-a", "volume-1")).To(Equal(2))
+	})
+
+	It("only calls onUnmount on the last Decrement for a key", func() {
+		onMount := func() (volman.MountResponse, error) { return volman.MountResponse{}, nil }
+		refCounter.Increment("plugin-a", "volume-1", onMount)
+		refCounter.Increment("plugin-a", "volume-1", onMount)
+
+		unmountCalls := 0
+		onUnmount := func() error { unmountCalls++; return nil }
+
+		Expect(refCounter.Decrement("plugin-a", "volume-1", onUnmount)).To(Succeed())
+		Expect(unmountCalls).To(Equal(0))
+		Expect(refCounter.Count("plugin-a", "volume-1")).To(Equal(1))
+
+		Expect(refCounter.Decrement("plugin-a", "volume-1", onUnmount)).To(Succeed())
+		Expect(unmountCalls).To(Equal(1))
+		Expect(refCounter.Count("plugin-a", "volume-1")).To(Equal(0))
+	})
+
+	It("does not block Increment for one key while onMount is in flight for another", func() {
+		blockA := make(chan struct{})
+		doneA := make(chan struct{})
+
+		go func() {
+			defer close(doneA)
+			refCounter.Increment("plugin-a", "volume-1", func() (volman.MountResponse, error) {
+				<-blockA
+				return volman.MountResponse{}, nil
+			})
+		}()
+
+		Eventually(func() (int, error) {
+			_, err := refCounter.Increment("plugin-b", "volume-2", func() (volman.MountResponse, error) {
+				return volman.MountResponse{}, nil
+			})
+			return refCounter.Count("plugin-b", "volume-2"), err
+		}, time.Second).Should(Equal(1))
+
+		close(blockA)
+		Eventually(doneA).Should(BeClosed())
+	})
+
+	It("persists and reloads the refcount table across restarts", func() {
+		refCounter.Increment("plugin-a", "volume-1", func() (volman.MountResponse, error) {
+			return volman.MountResponse{Path: "/some/path"}, nil
+		})
+
+		reloaded := newMountRefCounter(stateDir)
+		Expect(reloaded.Count("plugin-a", "volume-1")).To(Equal(1))
+	})
+
+	Describe("Reconcile", func() {
+		It("drops refcounts for volumes the plugin no longer reports", func() {
+			refCounter.Increment("plugin-a", "volume-1", func() (volman.MountResponse, error) {
+				return volman.MountResponse{}, nil
+			})
+			refCounter.Increment("plugin-a", "volume-2", func() (volman.MountResponse, error) {
+				return volman.MountResponse{}, nil
+			})
+
+			refCounter.Reconcile(lagertest.NewTestLogger("test"), "plugin-a", map[string]struct{}{"volume-1": {}})
+
+			Expect(refCounter.Count("plugin-a", "volume-1")).To(Equal(1))
+			Expect(refCounter.Count("plugin-a", "volume-2")).To(Equal(0))
+		})
+	})
+})
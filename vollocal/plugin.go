@@ -0,0 +1,105 @@
+package vollocal
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/voldriver"
+	"code.cloudfoundry.org/volman"
+)
+
+// driverPlugin adapts a voldriver.Driver (the interface a discovered plugin
+// binary satisfies) into a volman.Plugin (the interface vollocal drives
+// plugins through). Docker's Get-via-Create fallback is handled transport
+// side by driverhttp.RemoteClient; driverPlugin is responsible for the
+// manager-level equivalent for List, since synthesizing a useful List
+// response requires vollocal's own refcount table.
+type driverPlugin struct {
+	pluginId   string
+	driver     voldriver.Driver
+	refCounter *mountRefCounter
+	legacyList bool
+}
+
+// NewDriverPlugin wraps driver for pluginId, probing its List once up front
+// so the List fallback doesn't penalize modern drivers on every call.
+func NewDriverPlugin(logger lager.Logger, pluginId string, driver voldriver.Driver, refCounter *mountRefCounter) volman.Plugin {
+	logger = logger.Session("new-driver-plugin", lager.Data{"pluginId": pluginId})
+
+	return &driverPlugin{
+		pluginId:   pluginId,
+		driver:     driver,
+		refCounter: refCounter,
+		legacyList: voldriver.IsNotImplemented(driver.List(logger).Err),
+	}
+}
+
+func (p *driverPlugin) Mount(logger lager.Logger, volumeId string, config map[string]interface{}) (volman.MountResponse, error) {
+	mountResponse := p.driver.Mount(logger, voldriver.MountRequest{Name: volumeId})
+	if mountResponse.Err != "" {
+		return volman.MountResponse{}, errors.New(mountResponse.Err)
+	}
+	return volman.MountResponse{Path: mountResponse.Mountpoint}, nil
+}
+
+func (p *driverPlugin) Unmount(logger lager.Logger, volumeId string) error {
+	unmountResponse := p.driver.Unmount(logger, voldriver.UnmountRequest{Name: volumeId})
+	if unmountResponse.Err != "" {
+		return errors.New(unmountResponse.Err)
+	}
+	return nil
+}
+
+func (p *driverPlugin) List(logger lager.Logger) (volman.ListResponse, error) {
+	logger = logger.Session("list", lager.Data{"pluginId": p.pluginId})
+
+	if !p.legacyList {
+		listResponse := p.driver.List(logger)
+		if listResponse.Err == "" {
+			return toVolmanListResponse(listResponse), nil
+		}
+		if !voldriver.IsNotImplemented(listResponse.Err) {
+			return volman.ListResponse{}, errors.New(listResponse.Err)
+		}
+		logger.Info("driver-does-not-implement-list-synthesizing-from-refcounts")
+		p.legacyList = true
+	}
+
+	return volman.ListResponse{Volumes: p.refCounter.KnownVolumes(p.pluginId)}, nil
+}
+
+// IsLegacyList reports whether this plugin's List has been observed to be
+// unimplemented, meaning List itself falls back to the refcounter and so
+// can't be used as an independent source of truth for reconciliation.
+func (p *driverPlugin) IsLegacyList() bool {
+	return p.legacyList
+}
+
+// VerifyMounted asks the driver directly whether volumeId is still mounted,
+// via Get, bypassing List entirely. MountPurger uses this for legacy
+// drivers, since List on a legacyList driver just echoes the refcount
+// table Purge is trying to verify against.
+func (p *driverPlugin) VerifyMounted(logger lager.Logger, volumeId string) bool {
+	getResponse := p.driver.Get(logger, voldriver.GetRequest{Name: volumeId})
+	return getResponse.Err == ""
+}
+
+func (p *driverPlugin) Capabilities(logger lager.Logger) (volman.CapabilitiesResponse, error) {
+	capabilitiesResponse := p.driver.Capabilities(logger)
+	if capabilitiesResponse.Err != "" {
+		return volman.CapabilitiesResponse{}, errors.New(capabilitiesResponse.Err)
+	}
+	return volman.CapabilitiesResponse{Scope: capabilitiesResponse.Capabilities.Scope}, nil
+}
+
+func (p *driverPlugin) Matches(logger lager.Logger, spec volman.PluginSpec) bool {
+	return p.pluginId == spec.Name
+}
+
+func toVolmanListResponse(listResponse voldriver.ListResponse) volman.ListResponse {
+	volumes := make([]volman.VolumeInfo, 0, len(listResponse.Volumes))
+	for _, v := range listResponse.Volumes {
+		volumes = append(volumes, volman.VolumeInfo{Name: v.Name, Mountpoint: v.Mountpoint})
+	}
+	return volman.ListResponse{Volumes: volumes}
+}
@@ -0,0 +1,67 @@
+package vollocal
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/volman"
+)
+
+type pluginRegistry struct {
+	mutex   sync.RWMutex
+	plugins map[string]volman.Plugin
+}
+
+func NewPluginRegistry() volman.PluginRegistry {
+	return &pluginRegistry{
+		plugins: map[string]volman.Plugin{},
+	}
+}
+
+func (r *pluginRegistry) Plugin(pluginId string) (volman.Plugin, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	plugin, found := r.plugins[pluginId]
+	return plugin, found
+}
+
+func (r *pluginRegistry) Plugins() map[string]volman.Plugin {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	plugins := map[string]volman.Plugin{}
+	for id, plugin := range r.plugins {
+		plugins[id] = plugin
+	}
+	return plugins
+}
+
+func (r *pluginRegistry) Set(plugins map[string]volman.Plugin) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.plugins = plugins
+}
+
+// GlobalPlugins returns only the plugins that report a "global" scope, i.e.
+// whose volumes are visible cluster-wide and are therefore safe to share
+// across cells rather than mounted per-cell.
+func GlobalPlugins(logger lager.Logger, registry volman.PluginRegistry) map[string]volman.Plugin {
+	logger = logger.Session("global-plugins")
+	logger.Info("start")
+	defer logger.Info("end")
+
+	global := map[string]volman.Plugin{}
+	for id, plugin := range registry.Plugins() {
+		capabilities, err := plugin.Capabilities(logger)
+		if err != nil {
+			logger.Error("failed-getting-capabilities", err, lager.Data{"pluginId": id})
+			continue
+		}
+		if capabilities.Scope == "global" {
+			global[id] = plugin
+		}
+	}
+	return global
+}
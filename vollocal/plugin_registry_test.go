@@ -0,0 +1,60 @@
+package vollocal
+
+import (
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/voldriver"
+	"code.cloudfoundry.org/volman"
+	"code.cloudfoundry.org/volman/volmanfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pluginRegistry", func() {
+	var (
+		logger   = lagertest.NewTestLogger("plugin-registry-test")
+		registry volman.PluginRegistry
+	)
+
+	BeforeEach(func() {
+		registry = NewPluginRegistry()
+	})
+
+	It("starts out empty", func() {
+		Expect(registry.Plugins()).To(BeEmpty())
+		_, found := registry.Plugin("some-plugin")
+		Expect(found).To(BeFalse())
+	})
+
+	It("returns the plugins most recently Set", func() {
+		driver := &volmanfakes.FakeDriver{}
+		driver.ListReturns(voldriver.ListResponse{Err: "not implemented"})
+		plugin := NewDriverPlugin(logger, "some-plugin", driver, newMountRefCounter(""))
+
+		registry.Set(map[string]volman.Plugin{"some-plugin": plugin})
+
+		found, ok := registry.Plugin("some-plugin")
+		Expect(ok).To(BeTrue())
+		Expect(found).To(Equal(plugin))
+		Expect(registry.Plugins()).To(HaveLen(1))
+	})
+
+	Describe("GlobalPlugins", func() {
+		It("returns only plugins whose capabilities report a global scope", func() {
+			globalDriver := &volmanfakes.FakeDriver{}
+			globalDriver.ListReturns(voldriver.ListResponse{Err: "not implemented"})
+			globalDriver.CapabilitiesReturns(voldriver.CapabilitiesResponse{Capabilities: voldriver.CapabilityInfo{Scope: "global"}})
+			globalPlugin := NewDriverPlugin(logger, "global-plugin", globalDriver, newMountRefCounter(""))
+
+			localDriver := &volmanfakes.FakeDriver{}
+			localDriver.ListReturns(voldriver.ListResponse{Err: "not implemented"})
+			localDriver.CapabilitiesReturns(voldriver.CapabilitiesResponse{Capabilities: voldriver.CapabilityInfo{Scope: "local"}})
+			localPlugin := NewDriverPlugin(logger, "local-plugin", localDriver, newMountRefCounter(""))
+
+			registry.Set(map[string]volman.Plugin{"global-plugin": globalPlugin, "local-plugin": localPlugin})
+
+			global := GlobalPlugins(logger, registry)
+			Expect(global).To(HaveLen(1))
+			Expect(global).To(HaveKey("global-plugin"))
+		})
+	})
+})
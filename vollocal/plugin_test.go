@@ -0,0 +1,105 @@
+package vollocal
+
+import (
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/voldriver"
+	"code.cloudfoundry.org/volman"
+	"code.cloudfoundry.org/volman/volmanfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("driverPlugin", func() {
+	var (
+		logger     = lagertest.NewTestLogger("plugin-test")
+		driver     *volmanfakes.FakeDriver
+		refCounter *mountRefCounter
+		plugin     volman.Plugin
+	)
+
+	BeforeEach(func() {
+		driver = &volmanfakes.FakeDriver{}
+		refCounter = newMountRefCounter("")
+	})
+
+	Describe("Mount/Unmount", func() {
+		BeforeEach(func() {
+			driver.ListReturns(voldriver.ListResponse{Volumes: []voldriver.VolumeInfo{}})
+			plugin = NewDriverPlugin(logger, "some-plugin", driver, refCounter)
+		})
+
+		It("mounts through the driver", func() {
+			driver.MountReturns(voldriver.MountResponse{Mountpoint: "/some/path"})
+
+			response, err := plugin.Mount(logger, "some-volume", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Path).To(Equal("/some/path"))
+		})
+
+		It("surfaces a driver mount error", func() {
+			driver.MountReturns(voldriver.MountResponse{Err: "mount-failed"})
+
+			_, err := plugin.Mount(logger, "some-volume", nil)
+			Expect(err).To(MatchError("mount-failed"))
+		})
+
+		It("unmounts through the driver", func() {
+			driver.UnmountReturns(voldriver.ErrorResponse{})
+
+			Expect(plugin.Unmount(logger, "some-volume")).To(Succeed())
+		})
+	})
+
+	Describe("List", func() {
+		Context("when the driver implements List", func() {
+			BeforeEach(func() {
+				driver.ListReturns(voldriver.ListResponse{Volumes: []voldriver.VolumeInfo{{Name: "volume-1", Mountpoint: "/some/path"}}})
+				plugin = NewDriverPlugin(logger, "some-plugin", driver, refCounter)
+			})
+
+			It("returns the driver's list", func() {
+				response, err := plugin.List(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Volumes).To(Equal([]volman.VolumeInfo{{Name: "volume-1", Mountpoint: "/some/path"}}))
+			})
+		})
+
+		Context("when the driver doesn't implement List", func() {
+			BeforeEach(func() {
+				driver.ListReturns(voldriver.ListResponse{Err: "not implemented"})
+				plugin = NewDriverPlugin(logger, "some-plugin", driver, refCounter)
+
+				refCounter.Increment("some-plugin", "volume-1", func() (volman.MountResponse, error) {
+					return volman.MountResponse{Path: "/some/path"}, nil
+				})
+			})
+
+			It("synthesizes a List response from the refcount table", func() {
+				response, err := plugin.List(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Volumes).To(Equal([]volman.VolumeInfo{{Name: "volume-1", Mountpoint: "/some/path"}}))
+			})
+
+			It("reports itself as a legacy plugin", func() {
+				Expect(plugin.(*driverPlugin).IsLegacyList()).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("VerifyMounted", func() {
+		BeforeEach(func() {
+			driver.ListReturns(voldriver.ListResponse{Err: "not implemented"})
+			plugin = NewDriverPlugin(logger, "some-plugin", driver, refCounter)
+		})
+
+		It("reports true when the driver's Get succeeds", func() {
+			driver.GetReturns(voldriver.GetResponse{Volume: voldriver.VolumeInfo{Name: "volume-1"}})
+			Expect(plugin.(*driverPlugin).VerifyMounted(logger, "volume-1")).To(BeTrue())
+		})
+
+		It("reports false when the driver's Get errors", func() {
+			driver.GetReturns(voldriver.GetResponse{Err: "not found"})
+			Expect(plugin.(*driverPlugin).VerifyMounted(logger, "volume-1")).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,91 @@
+package vollocal
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/voldriver"
+)
+
+// RetryPolicy governs how localClient retries a Mount or Unmount call
+// against a plugin that's timing out or refusing connections, which is
+// common for network-backed plugins (NFS/EFS/CSI) under load.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Jitter         time.Duration
+	CallTimeout    time.Duration
+}
+
+func NewRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Jitter:         50 * time.Millisecond,
+		CallTimeout:    30 * time.Second,
+	}
+}
+
+// isRetryableError reports whether err looks like a transient, transport-
+// level failure (connection refused, timeout, 5xx-equivalent) rather than
+// something the plugin will never succeed on retry -- bad arguments, no
+// such volume, or an explicit voldriver.SafeError.
+func isRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := err.(voldriver.SafeError); ok {
+		return false
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, nonRetryable := range []string{"not found", "invalid", "does not exist"} {
+		if strings.Contains(msg, nonRetryable) {
+			return false
+		}
+	}
+	for _, retryable := range []string{"connection refused", "timeout", "timed out", "eof", "no such host", "i/o timeout", "502", "503", "504"} {
+		if strings.Contains(msg, retryable) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withRetry calls fn until it succeeds, fails non-retryably, exhausts
+// policy.MaxAttempts, or ctx is cancelled, backing off exponentially (with
+// jitter, capped at MaxBackoff) between retryable failures.
+func withRetry(ctx context.Context, retryClock clock.Clock, policy RetryPolicy, fn func() error) (attempts int, err error) {
+	backoff := policy.InitialBackoff
+
+	for attempts = 1; ; attempts++ {
+		err = fn()
+		if err == nil || attempts >= policy.MaxAttempts || !isRetryableError(err) {
+			return attempts, err
+		}
+
+		sleep := backoff
+		if policy.Jitter > 0 {
+			sleep += time.Duration(rand.Int63n(int64(policy.Jitter)))
+		}
+
+		timer := retryClock.NewTimer(sleep)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempts, ctx.Err()
+		case <-timer.C():
+		}
+
+		backoff *= 2
+		if backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
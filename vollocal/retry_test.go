@@ -0,0 +1,112 @@
+package vollocal
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/voldriver"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("isRetryableError", func() {
+	It("treats transport-level failures as retryable", func() {
+		Expect(isRetryableError(errors.New("dial tcp: connection refused"))).To(BeTrue())
+		Expect(isRetryableError(errors.New("context deadline exceeded: i/o timeout"))).To(BeTrue())
+		Expect(isRetryableError(errors.New("503 Service Unavailable"))).To(BeTrue())
+	})
+
+	It("treats permanent plugin failures as non-retryable", func() {
+		Expect(isRetryableError(errors.New("volume does not exist"))).To(BeFalse())
+		Expect(isRetryableError(errors.New("invalid config"))).To(BeFalse())
+	})
+
+	It("never retries a voldriver.SafeError", func() {
+		Expect(isRetryableError(voldriver.SafeError{SafeDescription: "connection refused"})).To(BeFalse())
+	})
+
+	It("returns false for a nil error", func() {
+		Expect(isRetryableError(nil)).To(BeFalse())
+	})
+})
+
+var _ = Describe("withRetry", func() {
+	var (
+		retryClock *fakeclock.FakeClock
+		policy     RetryPolicy
+	)
+
+	BeforeEach(func() {
+		retryClock = fakeclock.NewFakeClock(time.Now())
+		policy = RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Second,
+			MaxBackoff:     time.Second,
+			Jitter:         0,
+		}
+	})
+
+	It("returns immediately on success without sleeping", func() {
+		calls := 0
+		attempts, err := withRetry(context.Background(), retryClock, policy, func() error {
+			calls++
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(attempts).To(Equal(1))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("does not retry a non-retryable error", func() {
+		calls := 0
+		attempts, err := withRetry(context.Background(), retryClock, policy, func() error {
+			calls++
+			return errors.New("invalid volume")
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(attempts).To(Equal(1))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("retries a retryable error up to MaxAttempts, backing off between tries", func() {
+		calls := 0
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+			attempts, err := withRetry(context.Background(), retryClock, policy, func() error {
+				calls++
+				return errors.New("connection refused")
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(attempts).To(Equal(3))
+		}()
+
+		Eventually(retryClock.WatcherCount).Should(Equal(1))
+		retryClock.Increment(policy.InitialBackoff)
+		Eventually(retryClock.WatcherCount).Should(Equal(1))
+		retryClock.Increment(policy.InitialBackoff)
+
+		Eventually(done).Should(BeClosed())
+		Expect(calls).To(Equal(3))
+	})
+
+	It("stops retrying once the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+			_, err := withRetry(ctx, retryClock, policy, func() error {
+				return errors.New("connection refused")
+			})
+			Expect(err).To(Equal(context.Canceled))
+		}()
+
+		Eventually(retryClock.WatcherCount).Should(Equal(1))
+		cancel()
+		Eventually(done).Should(BeClosed())
+	})
+})
@@ -0,0 +1,154 @@
+package volhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/volman"
+	"github.com/tedsuo/rata"
+)
+
+// client implements volman.Manager by calling a remote volman over HTTP. It
+// lets a process that is not linking volman in-process (e.g. rep/executor
+// colocated with garden) still mount/unmount through a central volman.
+type client struct {
+	reqGen     *rata.RequestGenerator
+	httpClient *http.Client
+}
+
+func NewRemoteClient(volmanURL string, httpClient *http.Client) volman.Manager {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &client{
+		reqGen:     rata.NewRequestGenerator(volmanURL, Routes),
+		httpClient: httpClient,
+	}
+}
+
+func (c *client) ListDrivers(logger lager.Logger) (volman.ListDriversResponse, error) {
+	logger = logger.Session("list-drivers")
+	logger.Info("start")
+	defer logger.Info("end")
+
+	var response ListDriversResponse
+	if err := c.do(context.Background(), logger, ListDriversRoute, nil, nil, &response); err != nil {
+		return volman.ListDriversResponse{}, err
+	}
+	if response.Err != "" {
+		return volman.ListDriversResponse{}, errors.New(response.Err)
+	}
+	return response.ListDriversResponse, nil
+}
+
+func (c *client) ListVolumes(logger lager.Logger, pluginId string) (volman.ListResponse, error) {
+	logger = logger.Session("list-volumes")
+	logger.Info("start")
+	defer logger.Info("end")
+
+	var response ListVolumesResponse
+	if err := c.do(context.Background(), logger, ListVolumesRoute, nil, ListVolumesRequest{PluginId: pluginId}, &response); err != nil {
+		return volman.ListResponse{}, err
+	}
+	if response.Err != "" {
+		return volman.ListResponse{}, errors.New(response.Err)
+	}
+	return response.ListResponse, nil
+}
+
+func (c *client) GetCapabilities(logger lager.Logger, pluginId string) (volman.CapabilitiesResponse, error) {
+	logger = logger.Session("get-capabilities")
+	logger.Info("start")
+	defer logger.Info("end")
+
+	var response GetCapabilitiesResponse
+	if err := c.do(context.Background(), logger, GetCapabilitiesRoute, nil, GetCapabilitiesRequest{PluginId: pluginId}, &response); err != nil {
+		return volman.CapabilitiesResponse{}, err
+	}
+	if response.Err != "" {
+		return volman.CapabilitiesResponse{}, errors.New(response.Err)
+	}
+	return response.CapabilitiesResponse, nil
+}
+
+func (c *client) Mount(logger lager.Logger, pluginId string, volumeId string, config map[string]interface{}) (volman.MountResponse, error) {
+	return c.MountWithContext(context.Background(), logger, pluginId, volumeId, config)
+}
+
+func (c *client) MountWithContext(ctx context.Context, logger lager.Logger, pluginId string, volumeId string, config map[string]interface{}) (volman.MountResponse, error) {
+	logger = logger.Session("mount")
+	logger.Info("start")
+	defer logger.Info("end")
+
+	var response MountResponse
+	request := MountRequest{PluginId: pluginId, VolumeId: volumeId, Config: config}
+	if err := c.do(ctx, logger, MountRoute, nil, request, &response); err != nil {
+		return volman.MountResponse{}, err
+	}
+	if response.Err != "" {
+		return volman.MountResponse{}, errors.New(response.Err)
+	}
+	return response.MountResponse, nil
+}
+
+func (c *client) Unmount(logger lager.Logger, pluginId string, volumeId string) error {
+	return c.UnmountWithContext(context.Background(), logger, pluginId, volumeId)
+}
+
+func (c *client) UnmountWithContext(ctx context.Context, logger lager.Logger, pluginId string, volumeId string) error {
+	logger = logger.Session("unmount")
+	logger.Info("start")
+	defer logger.Info("end")
+
+	var response ErrorResponse
+	request := UnmountRequest{PluginId: pluginId, VolumeId: volumeId}
+	if err := c.do(ctx, logger, UnmountRoute, nil, request, &response); err != nil {
+		return err
+	}
+	if response.Err != "" {
+		return errors.New(response.Err)
+	}
+	return nil
+}
+
+func (c *client) do(ctx context.Context, logger lager.Logger, routeName string, params rata.Params, requestBody interface{}, responseBody interface{}) error {
+	var body *bytes.Reader
+	if requestBody != nil {
+		marshalled, err := json.Marshal(requestBody)
+		if err != nil {
+			logger.Error("failed-marshalling-request", err)
+			return err
+		}
+		body = bytes.NewReader(marshalled)
+	} else {
+		body = bytes.NewReader([]byte{})
+	}
+
+	httpRequest, err := c.reqGen.CreateRequest(routeName, params, body)
+	if err != nil {
+		logger.Error("failed-creating-request", err)
+		return err
+	}
+	httpRequest = httpRequest.WithContext(ctx)
+
+	httpResponse, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		logger.Error("failed-performing-request", err)
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	responseBytes, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		logger.Error("failed-reading-response-body", err)
+		return err
+	}
+
+	return json.Unmarshal(responseBytes, responseBody)
+}
@@ -0,0 +1,72 @@
+package volhttp_test
+
+import (
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/volman"
+	"code.cloudfoundry.org/volman/volhttp"
+	"code.cloudfoundry.org/volman/volmanfakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Volman HTTP Client", func() {
+	var (
+		testLogger = lagertest.NewTestLogger("ClientTest")
+		manager    *volmanfakes.FakeManager
+		server     *httptest.Server
+		client     volman.Manager
+	)
+
+	BeforeEach(func() {
+		manager = &volmanfakes.FakeManager{}
+		handler, err := volhttp.NewHandler(testLogger, manager)
+		Expect(err).NotTo(HaveOccurred())
+
+		server = httptest.NewServer(handler)
+		client = volhttp.NewRemoteClient(server.URL, nil)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("lists volumes through the remote manager", func() {
+		manager.ListVolumesReturns(volman.ListResponse{Volumes: []volman.VolumeInfo{{Name: "some-volume", Mountpoint: "/some/path"}}}, nil)
+
+		response, err := client.ListVolumes(testLogger, "some-plugin")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Volumes).To(Equal([]volman.VolumeInfo{{Name: "some-volume", Mountpoint: "/some/path"}}))
+
+		Expect(manager.ListVolumesCallCount()).To(Equal(1))
+	})
+
+	It("gets capabilities through the remote manager", func() {
+		manager.GetCapabilitiesReturns(volman.CapabilitiesResponse{Scope: "global"}, nil)
+
+		response, err := client.GetCapabilities(testLogger, "some-plugin")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Scope).To(Equal("global"))
+
+		Expect(manager.GetCapabilitiesCallCount()).To(Equal(1))
+	})
+
+	It("mounts a volume through the remote manager", func() {
+		manager.MountReturns(volman.MountResponse{Path: "dummy_path"}, nil)
+
+		response, err := client.Mount(testLogger, "some-plugin", "some-volume", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Path).To(Equal("dummy_path"))
+
+		Expect(manager.MountCallCount()).To(Equal(1))
+	})
+
+	It("unmounts a volume through the remote manager", func() {
+		manager.UnmountReturns(nil)
+
+		err := client.Unmount(testLogger, "some-plugin", "some-volume")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(manager.UnmountCallCount()).To(Equal(1))
+	})
+})
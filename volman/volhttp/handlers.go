@@ -0,0 +1,138 @@
+package volhttp
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	cf_http_handlers "github.com/cloudfoundry-incubator/cf_http/handlers"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/volman"
+	"github.com/tedsuo/rata"
+)
+
+// NewHandler wraps a volman.Manager in an HTTP JSON API, analogous to
+// driverhttp.NewHandler but for the manager surface rather than a single
+// driver. It lets a remote agent colocated with garden talk to a central
+// volman over HTTP instead of linking the manager in-process.
+func NewHandler(logger lager.Logger, manager volman.Manager) (http.Handler, error) {
+	logger = logger.Session("volman-server")
+	logger.Info("start")
+	defer logger.Info("end")
+
+	handlers := rata.Handlers{
+		ListDriversRoute: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			logger := logger.Session("handle-list-drivers")
+			logger.Info("start")
+			defer logger.Info("end")
+
+			listDriversResponse, err := manager.ListDrivers(logger)
+			if err != nil {
+				logger.Error("failed-listing-drivers", err)
+				cf_http_handlers.WriteJSONResponse(w, http.StatusInternalServerError, ListDriversResponse{Err: err.Error()})
+				return
+			}
+
+			cf_http_handlers.WriteJSONResponse(w, http.StatusOK, ListDriversResponse{ListDriversResponse: listDriversResponse})
+		}),
+
+		ListVolumesRoute: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			logger := logger.Session("handle-list-volumes")
+			logger.Info("start")
+			defer logger.Info("end")
+
+			var listVolumesRequest ListVolumesRequest
+			if err := unmarshalBody(req, &listVolumesRequest); err != nil {
+				logger.Error("failed-unmarshalling-list-volumes-request-body", err)
+				cf_http_handlers.WriteJSONResponse(w, http.StatusInternalServerError, ListVolumesResponse{Err: err.Error()})
+				return
+			}
+
+			listResponse, err := manager.ListVolumes(logger, listVolumesRequest.PluginId)
+			if err != nil {
+				logger.Error("failed-listing-volumes", err, lager.Data{"pluginId": listVolumesRequest.PluginId})
+				cf_http_handlers.WriteJSONResponse(w, http.StatusInternalServerError, ListVolumesResponse{Err: err.Error()})
+				return
+			}
+
+			cf_http_handlers.WriteJSONResponse(w, http.StatusOK, ListVolumesResponse{ListResponse: listResponse})
+		}),
+
+		GetCapabilitiesRoute: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			logger := logger.Session("handle-get-capabilities")
+			logger.Info("start")
+			defer logger.Info("end")
+
+			var getCapabilitiesRequest GetCapabilitiesRequest
+			if err := unmarshalBody(req, &getCapabilitiesRequest); err != nil {
+				logger.Error("failed-unmarshalling-get-capabilities-request-body", err)
+				cf_http_handlers.WriteJSONResponse(w, http.StatusInternalServerError, GetCapabilitiesResponse{Err: err.Error()})
+				return
+			}
+
+			capabilitiesResponse, err := manager.GetCapabilities(logger, getCapabilitiesRequest.PluginId)
+			if err != nil {
+				logger.Error("failed-getting-capabilities", err, lager.Data{"pluginId": getCapabilitiesRequest.PluginId})
+				cf_http_handlers.WriteJSONResponse(w, http.StatusInternalServerError, GetCapabilitiesResponse{Err: err.Error()})
+				return
+			}
+
+			cf_http_handlers.WriteJSONResponse(w, http.StatusOK, GetCapabilitiesResponse{CapabilitiesResponse: capabilitiesResponse})
+		}),
+
+		MountRoute: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			logger := logger.Session("handle-mount")
+			logger.Info("start")
+			defer logger.Info("end")
+
+			var mountRequest MountRequest
+			if err := unmarshalBody(req, &mountRequest); err != nil {
+				logger.Error("failed-unmarshalling-mount-request-body", err)
+				cf_http_handlers.WriteJSONResponse(w, http.StatusInternalServerError, MountResponse{Err: err.Error()})
+				return
+			}
+
+			mountResponse, err := manager.Mount(logger, mountRequest.PluginId, mountRequest.VolumeId, mountRequest.Config)
+			if err != nil {
+				logger.Error("failed-mounting-volume", err, lager.Data{"pluginId": mountRequest.PluginId, "volumeId": mountRequest.VolumeId})
+				cf_http_handlers.WriteJSONResponse(w, http.StatusInternalServerError, MountResponse{Err: err.Error()})
+				return
+			}
+
+			cf_http_handlers.WriteJSONResponse(w, http.StatusOK, MountResponse{MountResponse: mountResponse})
+		}),
+
+		UnmountRoute: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			logger := logger.Session("handle-unmount")
+			logger.Info("start")
+			defer logger.Info("end")
+
+			var unmountRequest UnmountRequest
+			if err := unmarshalBody(req, &unmountRequest); err != nil {
+				logger.Error("failed-unmarshalling-unmount-request-body", err)
+				cf_http_handlers.WriteJSONResponse(w, http.StatusInternalServerError, ErrorResponse{Err: err.Error()})
+				return
+			}
+
+			err := manager.Unmount(logger, unmountRequest.PluginId, unmountRequest.VolumeId)
+			if err != nil {
+				logger.Error("failed-unmounting-volume", err, lager.Data{"pluginId": unmountRequest.PluginId, "volumeId": unmountRequest.VolumeId})
+				cf_http_handlers.WriteJSONResponse(w, http.StatusInternalServerError, ErrorResponse{Err: err.Error()})
+				return
+			}
+
+			cf_http_handlers.WriteJSONResponse(w, http.StatusOK, ErrorResponse{})
+		}),
+	}
+
+	return rata.NewRouter(Routes, handlers)
+}
+
+func unmarshalBody(req *http.Request, dest interface{}) error {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, dest)
+}
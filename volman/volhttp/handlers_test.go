@@ -0,0 +1,176 @@
+package volhttp_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/volman"
+	"code.cloudfoundry.org/volman/volhttp"
+	"code.cloudfoundry.org/volman/volmanfakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Volman HTTP Handlers", func() {
+
+	Context("when generating http handlers", func() {
+		var testLogger = lagertest.NewTestLogger("HandlersTest")
+
+		It("should produce a handler with a list-drivers route", func() {
+			By("faking out the manager")
+			manager := &volmanfakes.FakeManager{}
+			manager.ListDriversReturns(volman.ListDriversResponse{Drivers: []volman.InfoResponse{{Name: "some-driver"}}}, nil)
+			handler, err := volhttp.NewHandler(testLogger, manager)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("then fake serving the response using the handler")
+			route, found := volhttp.Routes.FindRouteByName(volhttp.ListDriversRoute)
+			Expect(found).To(BeTrue())
+
+			path := fmt.Sprintf("http://0.0.0.0%s", route.Path)
+			httpRequest, err := http.NewRequest("GET", path, bytes.NewReader([]byte{}))
+			Expect(err).NotTo(HaveOccurred())
+
+			httpResponseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(httpResponseRecorder, httpRequest)
+
+			By("then deserialing the HTTP response")
+			response := volhttp.ListDriversResponse{}
+			body, err := ioutil.ReadAll(httpResponseRecorder.Body)
+			err = json.Unmarshal(body, &response)
+
+			By("then expecting correct JSON conversion")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Drivers).Should(Equal([]volman.InfoResponse{{Name: "some-driver"}}))
+		})
+
+		It("should produce a handler with a list-volumes route", func() {
+			By("faking out the manager")
+			manager := &volmanfakes.FakeManager{}
+			manager.ListVolumesReturns(volman.ListResponse{Volumes: []volman.VolumeInfo{{Name: "some-volume", Mountpoint: "/some/path"}}}, nil)
+			handler, err := volhttp.NewHandler(testLogger, manager)
+			Expect(err).NotTo(HaveOccurred())
+
+			listVolumesRequest := volhttp.ListVolumesRequest{PluginId: "some-plugin"}
+			listVolumesJSONRequest, err := json.Marshal(listVolumesRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("then fake serving the response using the handler")
+			route, found := volhttp.Routes.FindRouteByName(volhttp.ListVolumesRoute)
+			Expect(found).To(BeTrue())
+
+			path := fmt.Sprintf("http://0.0.0.0%s", route.Path)
+			httpRequest, err := http.NewRequest("POST", path, bytes.NewReader(listVolumesJSONRequest))
+			Expect(err).NotTo(HaveOccurred())
+
+			httpResponseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(httpResponseRecorder, httpRequest)
+
+			By("then deserialing the HTTP response")
+			response := volhttp.ListVolumesResponse{}
+			body, err := ioutil.ReadAll(httpResponseRecorder.Body)
+			err = json.Unmarshal(body, &response)
+
+			By("then expecting correct JSON conversion")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Volumes).Should(Equal([]volman.VolumeInfo{{Name: "some-volume", Mountpoint: "/some/path"}}))
+			Expect(manager.ListVolumesCallCount()).To(Equal(1))
+		})
+
+		It("should produce a handler with a get-capabilities route", func() {
+			By("faking out the manager")
+			manager := &volmanfakes.FakeManager{}
+			manager.GetCapabilitiesReturns(volman.CapabilitiesResponse{Scope: "global"}, nil)
+			handler, err := volhttp.NewHandler(testLogger, manager)
+			Expect(err).NotTo(HaveOccurred())
+
+			getCapabilitiesRequest := volhttp.GetCapabilitiesRequest{PluginId: "some-plugin"}
+			getCapabilitiesJSONRequest, err := json.Marshal(getCapabilitiesRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("then fake serving the response using the handler")
+			route, found := volhttp.Routes.FindRouteByName(volhttp.GetCapabilitiesRoute)
+			Expect(found).To(BeTrue())
+
+			path := fmt.Sprintf("http://0.0.0.0%s", route.Path)
+			httpRequest, err := http.NewRequest("POST", path, bytes.NewReader(getCapabilitiesJSONRequest))
+			Expect(err).NotTo(HaveOccurred())
+
+			httpResponseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(httpResponseRecorder, httpRequest)
+
+			By("then deserialing the HTTP response")
+			response := volhttp.GetCapabilitiesResponse{}
+			body, err := ioutil.ReadAll(httpResponseRecorder.Body)
+			err = json.Unmarshal(body, &response)
+
+			By("then expecting correct JSON conversion")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Scope).Should(Equal("global"))
+			Expect(manager.GetCapabilitiesCallCount()).To(Equal(1))
+		})
+
+		It("should produce a handler with a mount route", func() {
+			By("faking out the manager")
+			manager := &volmanfakes.FakeManager{}
+			manager.MountReturns(volman.MountResponse{Path: "dummy_path"}, nil)
+			handler, err := volhttp.NewHandler(testLogger, manager)
+			Expect(err).NotTo(HaveOccurred())
+
+			mountRequest := volhttp.MountRequest{PluginId: "some-plugin", VolumeId: "some-volume"}
+			mountJSONRequest, err := json.Marshal(mountRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("then fake serving the response using the handler")
+			route, found := volhttp.Routes.FindRouteByName(volhttp.MountRoute)
+			Expect(found).To(BeTrue())
+
+			path := fmt.Sprintf("http://0.0.0.0%s", route.Path)
+			httpRequest, err := http.NewRequest("POST", path, bytes.NewReader(mountJSONRequest))
+			Expect(err).NotTo(HaveOccurred())
+
+			httpResponseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(httpResponseRecorder, httpRequest)
+
+			By("then deserialing the HTTP response")
+			response := volhttp.MountResponse{}
+			body, err := ioutil.ReadAll(httpResponseRecorder.Body)
+			err = json.Unmarshal(body, &response)
+
+			By("then expecting correct JSON conversion")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Path).Should(Equal("dummy_path"))
+		})
+
+		It("should produce a handler with an unmount route", func() {
+			By("faking out the manager")
+			manager := &volmanfakes.FakeManager{}
+			manager.UnmountReturns(nil)
+			handler, err := volhttp.NewHandler(testLogger, manager)
+			Expect(err).NotTo(HaveOccurred())
+
+			unmountRequest := volhttp.UnmountRequest{PluginId: "some-plugin", VolumeId: "some-volume"}
+			unmountJSONRequest, err := json.Marshal(unmountRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("then fake serving the response using the handler")
+			route, found := volhttp.Routes.FindRouteByName(volhttp.UnmountRoute)
+			Expect(found).To(BeTrue())
+
+			path := fmt.Sprintf("http://0.0.0.0%s", route.Path)
+			httpRequest, err := http.NewRequest("POST", path, bytes.NewReader(unmountJSONRequest))
+			Expect(err).NotTo(HaveOccurred())
+
+			httpResponseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(httpResponseRecorder, httpRequest)
+
+			By("then expecting correct HTTP status code")
+			Expect(httpResponseRecorder.Code).To(Equal(200))
+		})
+	})
+})
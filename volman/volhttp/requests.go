@@ -0,0 +1,54 @@
+package volhttp
+
+import "code.cloudfoundry.org/volman"
+
+const (
+	ListDriversRoute    = "list-drivers"
+	ListVolumesRoute    = "list-volumes"
+	GetCapabilitiesRoute = "get-capabilities"
+	MountRoute          = "mount"
+	UnmountRoute        = "unmount"
+)
+
+type ListVolumesRequest struct {
+	PluginId string
+}
+
+type GetCapabilitiesRequest struct {
+	PluginId string
+}
+
+type MountRequest struct {
+	PluginId string
+	VolumeId string
+	Config   map[string]interface{}
+}
+
+type UnmountRequest struct {
+	PluginId string
+	VolumeId string
+}
+
+type ErrorResponse struct {
+	Err string `json:",omitempty"`
+}
+
+type ListDriversResponse struct {
+	volman.ListDriversResponse
+	Err string `json:",omitempty"`
+}
+
+type ListVolumesResponse struct {
+	volman.ListResponse
+	Err string `json:",omitempty"`
+}
+
+type GetCapabilitiesResponse struct {
+	volman.CapabilitiesResponse
+	Err string `json:",omitempty"`
+}
+
+type MountResponse struct {
+	volman.MountResponse
+	Err string `json:",omitempty"`
+}
@@ -0,0 +1,11 @@
+package volhttp
+
+import "github.com/tedsuo/rata"
+
+var Routes = rata.Routes{
+	{Path: "/ListDrivers", Method: "GET", Name: ListDriversRoute},
+	{Path: "/ListVolumes", Method: "POST", Name: ListVolumesRoute},
+	{Path: "/GetCapabilities", Method: "POST", Name: GetCapabilitiesRoute},
+	{Path: "/Mount", Method: "POST", Name: MountRoute},
+	{Path: "/Unmount", Method: "POST", Name: UnmountRoute},
+}
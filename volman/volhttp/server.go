@@ -0,0 +1,28 @@
+package volhttp
+
+import (
+	"crypto/tls"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/volman"
+	"github.com/cloudfoundry-incubator/cf_http"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/http_server"
+)
+
+// NewServer returns an ifrit.Runner that serves manager over HTTP on
+// listenAddr. When tlsConfig is non-nil the listener speaks TLS, using
+// cf_http's defaults for cipher suites and minimum version.
+func NewServer(logger lager.Logger, listenAddr string, manager volman.Manager, tlsConfig *tls.Config) (ifrit.Runner, error) {
+	handler, err := NewHandler(logger, manager)
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsConfig != nil {
+		tlsConfig = cf_http.NewTLSConfig(tlsConfig.Certificates, tlsConfig.RootCAs)
+		return http_server.NewTLSServer(listenAddr, handler, tlsConfig), nil
+	}
+
+	return http_server.New(listenAddr, handler), nil
+}
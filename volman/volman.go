@@ -0,0 +1,94 @@
+package volman
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/voldriver"
+)
+
+// Manager is the central point of contact for anything that wants to mount,
+// unmount, or enumerate volumes managed by the drivers volman has discovered.
+type Manager interface {
+	ListDrivers(logger lager.Logger) (ListDriversResponse, error)
+	ListVolumes(logger lager.Logger, pluginId string) (ListResponse, error)
+	GetCapabilities(logger lager.Logger, pluginId string) (CapabilitiesResponse, error)
+	Mount(logger lager.Logger, pluginId string, volumeId string, config map[string]interface{}) (MountResponse, error)
+	Unmount(logger lager.Logger, pluginId string, volumeId string) error
+
+	// MountWithContext and UnmountWithContext behave like Mount and
+	// Unmount, but let the caller bound or cancel the retry loop volman
+	// runs against a slow or flaky plugin instead of waiting it out.
+	MountWithContext(ctx context.Context, logger lager.Logger, pluginId string, volumeId string, config map[string]interface{}) (MountResponse, error)
+	UnmountWithContext(ctx context.Context, logger lager.Logger, pluginId string, volumeId string) error
+}
+
+type SafeError struct {
+	SafeDescription string
+}
+
+func (s SafeError) Error() string {
+	return s.SafeDescription
+}
+
+type InfoResponse struct {
+	Name string
+}
+
+type ListDriversResponse struct {
+	Drivers []InfoResponse
+}
+
+type VolumeInfo struct {
+	Name       string
+	Mountpoint string
+}
+
+// ListResponse enumerates the volumes known to a single plugin.
+type ListResponse struct {
+	Volumes []VolumeInfo
+}
+
+// CapabilitiesResponse describes what a single plugin supports. Scope is
+// "global" when the plugin's volumes are visible to every cell in the
+// cluster (e.g. shared NFS/EFS storage), or "local" when they only exist on
+// the cell the plugin is running on.
+type CapabilitiesResponse struct {
+	Scope string
+}
+
+type MountResponse struct {
+	Path string
+}
+
+// Discoverer finds drivers on disk or over the network, keyed by the plugin
+// id they should be registered under. It's up to the caller (vollocal's
+// Syncer) to wrap each one in a Plugin and add it to a PluginRegistry.
+type Discoverer interface {
+	Discover(logger lager.Logger) (map[string]voldriver.Driver, error)
+}
+
+// Plugin is volman's client-side view of a single discovered driver: the
+// subset of the Docker Volume Plugin protocol that vollocal needs in order to
+// drive it, independent of the transport (HTTP over TCP, HTTP over a unix
+// socket, etc.) used to reach it.
+type Plugin interface {
+	Mount(logger lager.Logger, volumeId string, config map[string]interface{}) (MountResponse, error)
+	Unmount(logger lager.Logger, volumeId string) error
+	List(logger lager.Logger) (ListResponse, error)
+	Capabilities(logger lager.Logger) (CapabilitiesResponse, error)
+	Matches(logger lager.Logger, spec PluginSpec) bool
+}
+
+type PluginSpec struct {
+	Name string
+}
+
+// PluginRegistry tracks the plugins volman has discovered, keyed by plugin
+// id (the name the discoverer found the plugin under, e.g. its socket file
+// name).
+type PluginRegistry interface {
+	Plugin(pluginId string) (Plugin, bool)
+	Plugins() map[string]Plugin
+	Set(plugins map[string]Plugin)
+}
@@ -0,0 +1,626 @@
+// This file was generated by counterfeiter
+package volmanfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/voldriver"
+)
+
+type FakeDriver struct {
+	ActivateStub        func(logger lager.Logger) voldriver.ActivateResponse
+	activateMutex       sync.RWMutex
+	activateArgsForCall []struct {
+		logger lager.Logger
+	}
+	activateReturns struct {
+		result1 voldriver.ActivateResponse
+	}
+	activateReturnsOnCall map[int]struct {
+		result1 voldriver.ActivateResponse
+	}
+
+	GetStub        func(logger lager.Logger, getRequest voldriver.GetRequest) voldriver.GetResponse
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		logger     lager.Logger
+		getRequest voldriver.GetRequest
+	}
+	getReturns struct {
+		result1 voldriver.GetResponse
+	}
+	getReturnsOnCall map[int]struct {
+		result1 voldriver.GetResponse
+	}
+
+	ListStub        func(logger lager.Logger) voldriver.ListResponse
+	listMutex       sync.RWMutex
+	listArgsForCall []struct {
+		logger lager.Logger
+	}
+	listReturns struct {
+		result1 voldriver.ListResponse
+	}
+	listReturnsOnCall map[int]struct {
+		result1 voldriver.ListResponse
+	}
+
+	CapabilitiesStub        func(logger lager.Logger) voldriver.CapabilitiesResponse
+	capabilitiesMutex       sync.RWMutex
+	capabilitiesArgsForCall []struct {
+		logger lager.Logger
+	}
+	capabilitiesReturns struct {
+		result1 voldriver.CapabilitiesResponse
+	}
+	capabilitiesReturnsOnCall map[int]struct {
+		result1 voldriver.CapabilitiesResponse
+	}
+
+	PathStub        func(logger lager.Logger, pathRequest voldriver.PathRequest) voldriver.PathResponse
+	pathMutex       sync.RWMutex
+	pathArgsForCall []struct {
+		logger      lager.Logger
+		pathRequest voldriver.PathRequest
+	}
+	pathReturns struct {
+		result1 voldriver.PathResponse
+	}
+	pathReturnsOnCall map[int]struct {
+		result1 voldriver.PathResponse
+	}
+
+	CreateStub        func(logger lager.Logger, createRequest voldriver.CreateRequest) voldriver.ErrorResponse
+	createMutex       sync.RWMutex
+	createArgsForCall []struct {
+		logger        lager.Logger
+		createRequest voldriver.CreateRequest
+	}
+	createReturns struct {
+		result1 voldriver.ErrorResponse
+	}
+	createReturnsOnCall map[int]struct {
+		result1 voldriver.ErrorResponse
+	}
+
+	MountStub        func(logger lager.Logger, mountRequest voldriver.MountRequest) voldriver.MountResponse
+	mountMutex       sync.RWMutex
+	mountArgsForCall []struct {
+		logger       lager.Logger
+		mountRequest voldriver.MountRequest
+	}
+	mountReturns struct {
+		result1 voldriver.MountResponse
+	}
+	mountReturnsOnCall map[int]struct {
+		result1 voldriver.MountResponse
+	}
+
+	UnmountStub        func(logger lager.Logger, unmountRequest voldriver.UnmountRequest) voldriver.ErrorResponse
+	unmountMutex       sync.RWMutex
+	unmountArgsForCall []struct {
+		logger         lager.Logger
+		unmountRequest voldriver.UnmountRequest
+	}
+	unmountReturns struct {
+		result1 voldriver.ErrorResponse
+	}
+	unmountReturnsOnCall map[int]struct {
+		result1 voldriver.ErrorResponse
+	}
+
+	RemoveStub        func(logger lager.Logger, removeRequest voldriver.RemoveRequest) voldriver.ErrorResponse
+	removeMutex       sync.RWMutex
+	removeArgsForCall []struct {
+		logger        lager.Logger
+		removeRequest voldriver.RemoveRequest
+	}
+	removeReturns struct {
+		result1 voldriver.ErrorResponse
+	}
+	removeReturnsOnCall map[int]struct {
+		result1 voldriver.ErrorResponse
+	}
+
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDriver) Activate(logger lager.Logger) voldriver.ActivateResponse {
+	fake.activateMutex.Lock()
+	ret, specificReturn := fake.activateReturnsOnCall[len(fake.activateArgsForCall)]
+	fake.activateArgsForCall = append(fake.activateArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.recordInvocation("Activate", []interface{}{logger})
+	fake.activateMutex.Unlock()
+	if fake.ActivateStub != nil {
+		return fake.ActivateStub(logger)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.activateReturns.result1
+}
+
+func (fake *FakeDriver) ActivateCallCount() int {
+	fake.activateMutex.RLock()
+	defer fake.activateMutex.RUnlock()
+	return len(fake.activateArgsForCall)
+}
+
+func (fake *FakeDriver) ActivateArgsForCall(i int) lager.Logger {
+	fake.activateMutex.RLock()
+	defer fake.activateMutex.RUnlock()
+	return fake.activateArgsForCall[i].logger
+}
+
+func (fake *FakeDriver) ActivateReturns(result1 voldriver.ActivateResponse) {
+	fake.ActivateStub = nil
+	fake.activateMutex.Lock()
+	defer fake.activateMutex.Unlock()
+	fake.activateReturns = struct {
+		result1 voldriver.ActivateResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) ActivateReturnsOnCall(i int, result1 voldriver.ActivateResponse) {
+	fake.ActivateStub = nil
+	fake.activateMutex.Lock()
+	defer fake.activateMutex.Unlock()
+	if fake.activateReturnsOnCall == nil {
+		fake.activateReturnsOnCall = make(map[int]struct {
+			result1 voldriver.ActivateResponse
+		})
+	}
+	fake.activateReturnsOnCall[i] = struct {
+		result1 voldriver.ActivateResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) Get(logger lager.Logger, getRequest voldriver.GetRequest) voldriver.GetResponse {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		logger     lager.Logger
+		getRequest voldriver.GetRequest
+	}{logger, getRequest})
+	fake.recordInvocation("Get", []interface{}{logger, getRequest})
+	fake.getMutex.Unlock()
+	if fake.GetStub != nil {
+		return fake.GetStub(logger, getRequest)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.getReturns.result1
+}
+
+func (fake *FakeDriver) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeDriver) GetArgsForCall(i int) (lager.Logger, voldriver.GetRequest) {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return fake.getArgsForCall[i].logger, fake.getArgsForCall[i].getRequest
+}
+
+func (fake *FakeDriver) GetReturns(result1 voldriver.GetResponse) {
+	fake.GetStub = nil
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.getReturns = struct {
+		result1 voldriver.GetResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) GetReturnsOnCall(i int, result1 voldriver.GetResponse) {
+	fake.GetStub = nil
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 voldriver.GetResponse
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 voldriver.GetResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) List(logger lager.Logger) voldriver.ListResponse {
+	fake.listMutex.Lock()
+	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
+	fake.listArgsForCall = append(fake.listArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.recordInvocation("List", []interface{}{logger})
+	fake.listMutex.Unlock()
+	if fake.ListStub != nil {
+		return fake.ListStub(logger)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.listReturns.result1
+}
+
+func (fake *FakeDriver) ListCallCount() int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return len(fake.listArgsForCall)
+}
+
+func (fake *FakeDriver) ListArgsForCall(i int) lager.Logger {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return fake.listArgsForCall[i].logger
+}
+
+func (fake *FakeDriver) ListReturns(result1 voldriver.ListResponse) {
+	fake.ListStub = nil
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.listReturns = struct {
+		result1 voldriver.ListResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) ListReturnsOnCall(i int, result1 voldriver.ListResponse) {
+	fake.ListStub = nil
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	if fake.listReturnsOnCall == nil {
+		fake.listReturnsOnCall = make(map[int]struct {
+			result1 voldriver.ListResponse
+		})
+	}
+	fake.listReturnsOnCall[i] = struct {
+		result1 voldriver.ListResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) Capabilities(logger lager.Logger) voldriver.CapabilitiesResponse {
+	fake.capabilitiesMutex.Lock()
+	ret, specificReturn := fake.capabilitiesReturnsOnCall[len(fake.capabilitiesArgsForCall)]
+	fake.capabilitiesArgsForCall = append(fake.capabilitiesArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.recordInvocation("Capabilities", []interface{}{logger})
+	fake.capabilitiesMutex.Unlock()
+	if fake.CapabilitiesStub != nil {
+		return fake.CapabilitiesStub(logger)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.capabilitiesReturns.result1
+}
+
+func (fake *FakeDriver) CapabilitiesCallCount() int {
+	fake.capabilitiesMutex.RLock()
+	defer fake.capabilitiesMutex.RUnlock()
+	return len(fake.capabilitiesArgsForCall)
+}
+
+func (fake *FakeDriver) CapabilitiesArgsForCall(i int) lager.Logger {
+	fake.capabilitiesMutex.RLock()
+	defer fake.capabilitiesMutex.RUnlock()
+	return fake.capabilitiesArgsForCall[i].logger
+}
+
+func (fake *FakeDriver) CapabilitiesReturns(result1 voldriver.CapabilitiesResponse) {
+	fake.CapabilitiesStub = nil
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.capabilitiesReturns = struct {
+		result1 voldriver.CapabilitiesResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) CapabilitiesReturnsOnCall(i int, result1 voldriver.CapabilitiesResponse) {
+	fake.CapabilitiesStub = nil
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	if fake.capabilitiesReturnsOnCall == nil {
+		fake.capabilitiesReturnsOnCall = make(map[int]struct {
+			result1 voldriver.CapabilitiesResponse
+		})
+	}
+	fake.capabilitiesReturnsOnCall[i] = struct {
+		result1 voldriver.CapabilitiesResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) Path(logger lager.Logger, pathRequest voldriver.PathRequest) voldriver.PathResponse {
+	fake.pathMutex.Lock()
+	ret, specificReturn := fake.pathReturnsOnCall[len(fake.pathArgsForCall)]
+	fake.pathArgsForCall = append(fake.pathArgsForCall, struct {
+		logger      lager.Logger
+		pathRequest voldriver.PathRequest
+	}{logger, pathRequest})
+	fake.recordInvocation("Path", []interface{}{logger, pathRequest})
+	fake.pathMutex.Unlock()
+	if fake.PathStub != nil {
+		return fake.PathStub(logger, pathRequest)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.pathReturns.result1
+}
+
+func (fake *FakeDriver) PathCallCount() int {
+	fake.pathMutex.RLock()
+	defer fake.pathMutex.RUnlock()
+	return len(fake.pathArgsForCall)
+}
+
+func (fake *FakeDriver) PathArgsForCall(i int) (lager.Logger, voldriver.PathRequest) {
+	fake.pathMutex.RLock()
+	defer fake.pathMutex.RUnlock()
+	return fake.pathArgsForCall[i].logger, fake.pathArgsForCall[i].pathRequest
+}
+
+func (fake *FakeDriver) PathReturns(result1 voldriver.PathResponse) {
+	fake.PathStub = nil
+	fake.pathMutex.Lock()
+	defer fake.pathMutex.Unlock()
+	fake.pathReturns = struct {
+		result1 voldriver.PathResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) PathReturnsOnCall(i int, result1 voldriver.PathResponse) {
+	fake.PathStub = nil
+	fake.pathMutex.Lock()
+	defer fake.pathMutex.Unlock()
+	if fake.pathReturnsOnCall == nil {
+		fake.pathReturnsOnCall = make(map[int]struct {
+			result1 voldriver.PathResponse
+		})
+	}
+	fake.pathReturnsOnCall[i] = struct {
+		result1 voldriver.PathResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) Create(logger lager.Logger, createRequest voldriver.CreateRequest) voldriver.ErrorResponse {
+	fake.createMutex.Lock()
+	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
+	fake.createArgsForCall = append(fake.createArgsForCall, struct {
+		logger        lager.Logger
+		createRequest voldriver.CreateRequest
+	}{logger, createRequest})
+	fake.recordInvocation("Create", []interface{}{logger, createRequest})
+	fake.createMutex.Unlock()
+	if fake.CreateStub != nil {
+		return fake.CreateStub(logger, createRequest)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.createReturns.result1
+}
+
+func (fake *FakeDriver) CreateCallCount() int {
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	return len(fake.createArgsForCall)
+}
+
+func (fake *FakeDriver) CreateArgsForCall(i int) (lager.Logger, voldriver.CreateRequest) {
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	return fake.createArgsForCall[i].logger, fake.createArgsForCall[i].createRequest
+}
+
+func (fake *FakeDriver) CreateReturns(result1 voldriver.ErrorResponse) {
+	fake.CreateStub = nil
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.createReturns = struct {
+		result1 voldriver.ErrorResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) CreateReturnsOnCall(i int, result1 voldriver.ErrorResponse) {
+	fake.CreateStub = nil
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	if fake.createReturnsOnCall == nil {
+		fake.createReturnsOnCall = make(map[int]struct {
+			result1 voldriver.ErrorResponse
+		})
+	}
+	fake.createReturnsOnCall[i] = struct {
+		result1 voldriver.ErrorResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) Mount(logger lager.Logger, mountRequest voldriver.MountRequest) voldriver.MountResponse {
+	fake.mountMutex.Lock()
+	ret, specificReturn := fake.mountReturnsOnCall[len(fake.mountArgsForCall)]
+	fake.mountArgsForCall = append(fake.mountArgsForCall, struct {
+		logger       lager.Logger
+		mountRequest voldriver.MountRequest
+	}{logger, mountRequest})
+	fake.recordInvocation("Mount", []interface{}{logger, mountRequest})
+	fake.mountMutex.Unlock()
+	if fake.MountStub != nil {
+		return fake.MountStub(logger, mountRequest)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.mountReturns.result1
+}
+
+func (fake *FakeDriver) MountCallCount() int {
+	fake.mountMutex.RLock()
+	defer fake.mountMutex.RUnlock()
+	return len(fake.mountArgsForCall)
+}
+
+func (fake *FakeDriver) MountArgsForCall(i int) (lager.Logger, voldriver.MountRequest) {
+	fake.mountMutex.RLock()
+	defer fake.mountMutex.RUnlock()
+	return fake.mountArgsForCall[i].logger, fake.mountArgsForCall[i].mountRequest
+}
+
+func (fake *FakeDriver) MountReturns(result1 voldriver.MountResponse) {
+	fake.MountStub = nil
+	fake.mountMutex.Lock()
+	defer fake.mountMutex.Unlock()
+	fake.mountReturns = struct {
+		result1 voldriver.MountResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) MountReturnsOnCall(i int, result1 voldriver.MountResponse) {
+	fake.MountStub = nil
+	fake.mountMutex.Lock()
+	defer fake.mountMutex.Unlock()
+	if fake.mountReturnsOnCall == nil {
+		fake.mountReturnsOnCall = make(map[int]struct {
+			result1 voldriver.MountResponse
+		})
+	}
+	fake.mountReturnsOnCall[i] = struct {
+		result1 voldriver.MountResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) Unmount(logger lager.Logger, unmountRequest voldriver.UnmountRequest) voldriver.ErrorResponse {
+	fake.unmountMutex.Lock()
+	ret, specificReturn := fake.unmountReturnsOnCall[len(fake.unmountArgsForCall)]
+	fake.unmountArgsForCall = append(fake.unmountArgsForCall, struct {
+		logger         lager.Logger
+		unmountRequest voldriver.UnmountRequest
+	}{logger, unmountRequest})
+	fake.recordInvocation("Unmount", []interface{}{logger, unmountRequest})
+	fake.unmountMutex.Unlock()
+	if fake.UnmountStub != nil {
+		return fake.UnmountStub(logger, unmountRequest)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.unmountReturns.result1
+}
+
+func (fake *FakeDriver) UnmountCallCount() int {
+	fake.unmountMutex.RLock()
+	defer fake.unmountMutex.RUnlock()
+	return len(fake.unmountArgsForCall)
+}
+
+func (fake *FakeDriver) UnmountArgsForCall(i int) (lager.Logger, voldriver.UnmountRequest) {
+	fake.unmountMutex.RLock()
+	defer fake.unmountMutex.RUnlock()
+	return fake.unmountArgsForCall[i].logger, fake.unmountArgsForCall[i].unmountRequest
+}
+
+func (fake *FakeDriver) UnmountReturns(result1 voldriver.ErrorResponse) {
+	fake.UnmountStub = nil
+	fake.unmountMutex.Lock()
+	defer fake.unmountMutex.Unlock()
+	fake.unmountReturns = struct {
+		result1 voldriver.ErrorResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) UnmountReturnsOnCall(i int, result1 voldriver.ErrorResponse) {
+	fake.UnmountStub = nil
+	fake.unmountMutex.Lock()
+	defer fake.unmountMutex.Unlock()
+	if fake.unmountReturnsOnCall == nil {
+		fake.unmountReturnsOnCall = make(map[int]struct {
+			result1 voldriver.ErrorResponse
+		})
+	}
+	fake.unmountReturnsOnCall[i] = struct {
+		result1 voldriver.ErrorResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) Remove(logger lager.Logger, removeRequest voldriver.RemoveRequest) voldriver.ErrorResponse {
+	fake.removeMutex.Lock()
+	ret, specificReturn := fake.removeReturnsOnCall[len(fake.removeArgsForCall)]
+	fake.removeArgsForCall = append(fake.removeArgsForCall, struct {
+		logger        lager.Logger
+		removeRequest voldriver.RemoveRequest
+	}{logger, removeRequest})
+	fake.recordInvocation("Remove", []interface{}{logger, removeRequest})
+	fake.removeMutex.Unlock()
+	if fake.RemoveStub != nil {
+		return fake.RemoveStub(logger, removeRequest)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.removeReturns.result1
+}
+
+func (fake *FakeDriver) RemoveCallCount() int {
+	fake.removeMutex.RLock()
+	defer fake.removeMutex.RUnlock()
+	return len(fake.removeArgsForCall)
+}
+
+func (fake *FakeDriver) RemoveArgsForCall(i int) (lager.Logger, voldriver.RemoveRequest) {
+	fake.removeMutex.RLock()
+	defer fake.removeMutex.RUnlock()
+	return fake.removeArgsForCall[i].logger, fake.removeArgsForCall[i].removeRequest
+}
+
+func (fake *FakeDriver) RemoveReturns(result1 voldriver.ErrorResponse) {
+	fake.RemoveStub = nil
+	fake.removeMutex.Lock()
+	defer fake.removeMutex.Unlock()
+	fake.removeReturns = struct {
+		result1 voldriver.ErrorResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) RemoveReturnsOnCall(i int, result1 voldriver.ErrorResponse) {
+	fake.RemoveStub = nil
+	fake.removeMutex.Lock()
+	defer fake.removeMutex.Unlock()
+	if fake.removeReturnsOnCall == nil {
+		fake.removeReturnsOnCall = make(map[int]struct {
+			result1 voldriver.ErrorResponse
+		})
+	}
+	fake.removeReturnsOnCall[i] = struct {
+		result1 voldriver.ErrorResponse
+	}{result1}
+}
+
+func (fake *FakeDriver) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDriver) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ voldriver.Driver = new(FakeDriver)
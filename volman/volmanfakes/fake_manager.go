@@ -0,0 +1,538 @@
+// This file was generated by counterfeiter
+package volmanfakes
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/volman"
+)
+
+type FakeManager struct {
+	ListDriversStub        func(logger lager.Logger) (volman.ListDriversResponse, error)
+	listDriversMutex       sync.RWMutex
+	listDriversArgsForCall []struct {
+		logger lager.Logger
+	}
+	listDriversReturns struct {
+		result1 volman.ListDriversResponse
+		result2 error
+	}
+	listDriversReturnsOnCall map[int]struct {
+		result1 volman.ListDriversResponse
+		result2 error
+	}
+
+	ListVolumesStub        func(logger lager.Logger, pluginId string) (volman.ListResponse, error)
+	listVolumesMutex       sync.RWMutex
+	listVolumesArgsForCall []struct {
+		logger   lager.Logger
+		pluginId string
+	}
+	listVolumesReturns struct {
+		result1 volman.ListResponse
+		result2 error
+	}
+	listVolumesReturnsOnCall map[int]struct {
+		result1 volman.ListResponse
+		result2 error
+	}
+
+	GetCapabilitiesStub        func(logger lager.Logger, pluginId string) (volman.CapabilitiesResponse, error)
+	getCapabilitiesMutex       sync.RWMutex
+	getCapabilitiesArgsForCall []struct {
+		logger   lager.Logger
+		pluginId string
+	}
+	getCapabilitiesReturns struct {
+		result1 volman.CapabilitiesResponse
+		result2 error
+	}
+	getCapabilitiesReturnsOnCall map[int]struct {
+		result1 volman.CapabilitiesResponse
+		result2 error
+	}
+
+	MountStub        func(logger lager.Logger, pluginId string, volumeId string, config map[string]interface{}) (volman.MountResponse, error)
+	mountMutex       sync.RWMutex
+	mountArgsForCall []struct {
+		logger   lager.Logger
+		pluginId string
+		volumeId string
+		config   map[string]interface{}
+	}
+	mountReturns struct {
+		result1 volman.MountResponse
+		result2 error
+	}
+	mountReturnsOnCall map[int]struct {
+		result1 volman.MountResponse
+		result2 error
+	}
+
+	UnmountStub        func(logger lager.Logger, pluginId string, volumeId string) error
+	unmountMutex       sync.RWMutex
+	unmountArgsForCall []struct {
+		logger   lager.Logger
+		pluginId string
+		volumeId string
+	}
+	unmountReturns struct {
+		result1 error
+	}
+	unmountReturnsOnCall map[int]struct {
+		result1 error
+	}
+
+	MountWithContextStub        func(ctx context.Context, logger lager.Logger, pluginId string, volumeId string, config map[string]interface{}) (volman.MountResponse, error)
+	mountWithContextMutex       sync.RWMutex
+	mountWithContextArgsForCall []struct {
+		ctx      context.Context
+		logger   lager.Logger
+		pluginId string
+		volumeId string
+		config   map[string]interface{}
+	}
+	mountWithContextReturns struct {
+		result1 volman.MountResponse
+		result2 error
+	}
+	mountWithContextReturnsOnCall map[int]struct {
+		result1 volman.MountResponse
+		result2 error
+	}
+
+	UnmountWithContextStub        func(ctx context.Context, logger lager.Logger, pluginId string, volumeId string) error
+	unmountWithContextMutex       sync.RWMutex
+	unmountWithContextArgsForCall []struct {
+		ctx      context.Context
+		logger   lager.Logger
+		pluginId string
+		volumeId string
+	}
+	unmountWithContextReturns struct {
+		result1 error
+	}
+	unmountWithContextReturnsOnCall map[int]struct {
+		result1 error
+	}
+
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeManager) ListDrivers(logger lager.Logger) (volman.ListDriversResponse, error) {
+	fake.listDriversMutex.Lock()
+	ret, specificReturn := fake.listDriversReturnsOnCall[len(fake.listDriversArgsForCall)]
+	fake.listDriversArgsForCall = append(fake.listDriversArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.recordInvocation("ListDrivers", []interface{}{logger})
+	fake.listDriversMutex.Unlock()
+	if fake.ListDriversStub != nil {
+		return fake.ListDriversStub(logger)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.listDriversReturns.result1, fake.listDriversReturns.result2
+}
+
+func (fake *FakeManager) ListDriversCallCount() int {
+	fake.listDriversMutex.RLock()
+	defer fake.listDriversMutex.RUnlock()
+	return len(fake.listDriversArgsForCall)
+}
+
+func (fake *FakeManager) ListDriversReturns(result1 volman.ListDriversResponse, result2 error) {
+	fake.ListDriversStub = nil
+	fake.listDriversMutex.Lock()
+	defer fake.listDriversMutex.Unlock()
+	fake.listDriversReturns = struct {
+		result1 volman.ListDriversResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeManager) ListDriversReturnsOnCall(i int, result1 volman.ListDriversResponse, result2 error) {
+	fake.ListDriversStub = nil
+	fake.listDriversMutex.Lock()
+	defer fake.listDriversMutex.Unlock()
+	if fake.listDriversReturnsOnCall == nil {
+		fake.listDriversReturnsOnCall = make(map[int]struct {
+			result1 volman.ListDriversResponse
+			result2 error
+		})
+	}
+	fake.listDriversReturnsOnCall[i] = struct {
+		result1 volman.ListDriversResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeManager) ListVolumes(logger lager.Logger, pluginId string) (volman.ListResponse, error) {
+	fake.listVolumesMutex.Lock()
+	ret, specificReturn := fake.listVolumesReturnsOnCall[len(fake.listVolumesArgsForCall)]
+	fake.listVolumesArgsForCall = append(fake.listVolumesArgsForCall, struct {
+		logger   lager.Logger
+		pluginId string
+	}{logger, pluginId})
+	fake.recordInvocation("ListVolumes", []interface{}{logger, pluginId})
+	fake.listVolumesMutex.Unlock()
+	if fake.ListVolumesStub != nil {
+		return fake.ListVolumesStub(logger, pluginId)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.listVolumesReturns.result1, fake.listVolumesReturns.result2
+}
+
+func (fake *FakeManager) ListVolumesCallCount() int {
+	fake.listVolumesMutex.RLock()
+	defer fake.listVolumesMutex.RUnlock()
+	return len(fake.listVolumesArgsForCall)
+}
+
+func (fake *FakeManager) ListVolumesArgsForCall(i int) (lager.Logger, string) {
+	fake.listVolumesMutex.RLock()
+	defer fake.listVolumesMutex.RUnlock()
+	return fake.listVolumesArgsForCall[i].logger, fake.listVolumesArgsForCall[i].pluginId
+}
+
+func (fake *FakeManager) ListVolumesReturns(result1 volman.ListResponse, result2 error) {
+	fake.ListVolumesStub = nil
+	fake.listVolumesMutex.Lock()
+	defer fake.listVolumesMutex.Unlock()
+	fake.listVolumesReturns = struct {
+		result1 volman.ListResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeManager) ListVolumesReturnsOnCall(i int, result1 volman.ListResponse, result2 error) {
+	fake.ListVolumesStub = nil
+	fake.listVolumesMutex.Lock()
+	defer fake.listVolumesMutex.Unlock()
+	if fake.listVolumesReturnsOnCall == nil {
+		fake.listVolumesReturnsOnCall = make(map[int]struct {
+			result1 volman.ListResponse
+			result2 error
+		})
+	}
+	fake.listVolumesReturnsOnCall[i] = struct {
+		result1 volman.ListResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeManager) GetCapabilities(logger lager.Logger, pluginId string) (volman.CapabilitiesResponse, error) {
+	fake.getCapabilitiesMutex.Lock()
+	ret, specificReturn := fake.getCapabilitiesReturnsOnCall[len(fake.getCapabilitiesArgsForCall)]
+	fake.getCapabilitiesArgsForCall = append(fake.getCapabilitiesArgsForCall, struct {
+		logger   lager.Logger
+		pluginId string
+	}{logger, pluginId})
+	fake.recordInvocation("GetCapabilities", []interface{}{logger, pluginId})
+	fake.getCapabilitiesMutex.Unlock()
+	if fake.GetCapabilitiesStub != nil {
+		return fake.GetCapabilitiesStub(logger, pluginId)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getCapabilitiesReturns.result1, fake.getCapabilitiesReturns.result2
+}
+
+func (fake *FakeManager) GetCapabilitiesCallCount() int {
+	fake.getCapabilitiesMutex.RLock()
+	defer fake.getCapabilitiesMutex.RUnlock()
+	return len(fake.getCapabilitiesArgsForCall)
+}
+
+func (fake *FakeManager) GetCapabilitiesArgsForCall(i int) (lager.Logger, string) {
+	fake.getCapabilitiesMutex.RLock()
+	defer fake.getCapabilitiesMutex.RUnlock()
+	return fake.getCapabilitiesArgsForCall[i].logger, fake.getCapabilitiesArgsForCall[i].pluginId
+}
+
+func (fake *FakeManager) GetCapabilitiesReturns(result1 volman.CapabilitiesResponse, result2 error) {
+	fake.GetCapabilitiesStub = nil
+	fake.getCapabilitiesMutex.Lock()
+	defer fake.getCapabilitiesMutex.Unlock()
+	fake.getCapabilitiesReturns = struct {
+		result1 volman.CapabilitiesResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeManager) GetCapabilitiesReturnsOnCall(i int, result1 volman.CapabilitiesResponse, result2 error) {
+	fake.GetCapabilitiesStub = nil
+	fake.getCapabilitiesMutex.Lock()
+	defer fake.getCapabilitiesMutex.Unlock()
+	if fake.getCapabilitiesReturnsOnCall == nil {
+		fake.getCapabilitiesReturnsOnCall = make(map[int]struct {
+			result1 volman.CapabilitiesResponse
+			result2 error
+		})
+	}
+	fake.getCapabilitiesReturnsOnCall[i] = struct {
+		result1 volman.CapabilitiesResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeManager) Mount(logger lager.Logger, pluginId string, volumeId string, config map[string]interface{}) (volman.MountResponse, error) {
+	fake.mountMutex.Lock()
+	ret, specificReturn := fake.mountReturnsOnCall[len(fake.mountArgsForCall)]
+	fake.mountArgsForCall = append(fake.mountArgsForCall, struct {
+		logger   lager.Logger
+		pluginId string
+		volumeId string
+		config   map[string]interface{}
+	}{logger, pluginId, volumeId, config})
+	fake.recordInvocation("Mount", []interface{}{logger, pluginId, volumeId, config})
+	fake.mountMutex.Unlock()
+	if fake.MountStub != nil {
+		return fake.MountStub(logger, pluginId, volumeId, config)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.mountReturns.result1, fake.mountReturns.result2
+}
+
+func (fake *FakeManager) MountCallCount() int {
+	fake.mountMutex.RLock()
+	defer fake.mountMutex.RUnlock()
+	return len(fake.mountArgsForCall)
+}
+
+func (fake *FakeManager) MountArgsForCall(i int) (lager.Logger, string, string, map[string]interface{}) {
+	fake.mountMutex.RLock()
+	defer fake.mountMutex.RUnlock()
+	a := fake.mountArgsForCall[i]
+	return a.logger, a.pluginId, a.volumeId, a.config
+}
+
+func (fake *FakeManager) MountReturns(result1 volman.MountResponse, result2 error) {
+	fake.MountStub = nil
+	fake.mountMutex.Lock()
+	defer fake.mountMutex.Unlock()
+	fake.mountReturns = struct {
+		result1 volman.MountResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeManager) MountReturnsOnCall(i int, result1 volman.MountResponse, result2 error) {
+	fake.MountStub = nil
+	fake.mountMutex.Lock()
+	defer fake.mountMutex.Unlock()
+	if fake.mountReturnsOnCall == nil {
+		fake.mountReturnsOnCall = make(map[int]struct {
+			result1 volman.MountResponse
+			result2 error
+		})
+	}
+	fake.mountReturnsOnCall[i] = struct {
+		result1 volman.MountResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeManager) Unmount(logger lager.Logger, pluginId string, volumeId string) error {
+	fake.unmountMutex.Lock()
+	ret, specificReturn := fake.unmountReturnsOnCall[len(fake.unmountArgsForCall)]
+	fake.unmountArgsForCall = append(fake.unmountArgsForCall, struct {
+		logger   lager.Logger
+		pluginId string
+		volumeId string
+	}{logger, pluginId, volumeId})
+	fake.recordInvocation("Unmount", []interface{}{logger, pluginId, volumeId})
+	fake.unmountMutex.Unlock()
+	if fake.UnmountStub != nil {
+		return fake.UnmountStub(logger, pluginId, volumeId)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.unmountReturns.result1
+}
+
+func (fake *FakeManager) UnmountCallCount() int {
+	fake.unmountMutex.RLock()
+	defer fake.unmountMutex.RUnlock()
+	return len(fake.unmountArgsForCall)
+}
+
+func (fake *FakeManager) UnmountArgsForCall(i int) (lager.Logger, string, string) {
+	fake.unmountMutex.RLock()
+	defer fake.unmountMutex.RUnlock()
+	a := fake.unmountArgsForCall[i]
+	return a.logger, a.pluginId, a.volumeId
+}
+
+func (fake *FakeManager) UnmountReturns(result1 error) {
+	fake.UnmountStub = nil
+	fake.unmountMutex.Lock()
+	defer fake.unmountMutex.Unlock()
+	fake.unmountReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) UnmountReturnsOnCall(i int, result1 error) {
+	fake.UnmountStub = nil
+	fake.unmountMutex.Lock()
+	defer fake.unmountMutex.Unlock()
+	if fake.unmountReturnsOnCall == nil {
+		fake.unmountReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unmountReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) MountWithContext(ctx context.Context, logger lager.Logger, pluginId string, volumeId string, config map[string]interface{}) (volman.MountResponse, error) {
+	fake.mountWithContextMutex.Lock()
+	ret, specificReturn := fake.mountWithContextReturnsOnCall[len(fake.mountWithContextArgsForCall)]
+	fake.mountWithContextArgsForCall = append(fake.mountWithContextArgsForCall, struct {
+		ctx      context.Context
+		logger   lager.Logger
+		pluginId string
+		volumeId string
+		config   map[string]interface{}
+	}{ctx, logger, pluginId, volumeId, config})
+	fake.recordInvocation("MountWithContext", []interface{}{ctx, logger, pluginId, volumeId, config})
+	fake.mountWithContextMutex.Unlock()
+	if fake.MountWithContextStub != nil {
+		return fake.MountWithContextStub(ctx, logger, pluginId, volumeId, config)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.mountWithContextReturns.result1, fake.mountWithContextReturns.result2
+}
+
+func (fake *FakeManager) MountWithContextCallCount() int {
+	fake.mountWithContextMutex.RLock()
+	defer fake.mountWithContextMutex.RUnlock()
+	return len(fake.mountWithContextArgsForCall)
+}
+
+func (fake *FakeManager) MountWithContextArgsForCall(i int) (context.Context, lager.Logger, string, string, map[string]interface{}) {
+	fake.mountWithContextMutex.RLock()
+	defer fake.mountWithContextMutex.RUnlock()
+	a := fake.mountWithContextArgsForCall[i]
+	return a.ctx, a.logger, a.pluginId, a.volumeId, a.config
+}
+
+func (fake *FakeManager) MountWithContextReturns(result1 volman.MountResponse, result2 error) {
+	fake.MountWithContextStub = nil
+	fake.mountWithContextMutex.Lock()
+	defer fake.mountWithContextMutex.Unlock()
+	fake.mountWithContextReturns = struct {
+		result1 volman.MountResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeManager) MountWithContextReturnsOnCall(i int, result1 volman.MountResponse, result2 error) {
+	fake.MountWithContextStub = nil
+	fake.mountWithContextMutex.Lock()
+	defer fake.mountWithContextMutex.Unlock()
+	if fake.mountWithContextReturnsOnCall == nil {
+		fake.mountWithContextReturnsOnCall = make(map[int]struct {
+			result1 volman.MountResponse
+			result2 error
+		})
+	}
+	fake.mountWithContextReturnsOnCall[i] = struct {
+		result1 volman.MountResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeManager) UnmountWithContext(ctx context.Context, logger lager.Logger, pluginId string, volumeId string) error {
+	fake.unmountWithContextMutex.Lock()
+	ret, specificReturn := fake.unmountWithContextReturnsOnCall[len(fake.unmountWithContextArgsForCall)]
+	fake.unmountWithContextArgsForCall = append(fake.unmountWithContextArgsForCall, struct {
+		ctx      context.Context
+		logger   lager.Logger
+		pluginId string
+		volumeId string
+	}{ctx, logger, pluginId, volumeId})
+	fake.recordInvocation("UnmountWithContext", []interface{}{ctx, logger, pluginId, volumeId})
+	fake.unmountWithContextMutex.Unlock()
+	if fake.UnmountWithContextStub != nil {
+		return fake.UnmountWithContextStub(ctx, logger, pluginId, volumeId)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.unmountWithContextReturns.result1
+}
+
+func (fake *FakeManager) UnmountWithContextCallCount() int {
+	fake.unmountWithContextMutex.RLock()
+	defer fake.unmountWithContextMutex.RUnlock()
+	return len(fake.unmountWithContextArgsForCall)
+}
+
+func (fake *FakeManager) UnmountWithContextArgsForCall(i int) (context.Context, lager.Logger, string, string) {
+	fake.unmountWithContextMutex.RLock()
+	defer fake.unmountWithContextMutex.RUnlock()
+	a := fake.unmountWithContextArgsForCall[i]
+	return a.ctx, a.logger, a.pluginId, a.volumeId
+}
+
+func (fake *FakeManager) UnmountWithContextReturns(result1 error) {
+	fake.UnmountWithContextStub = nil
+	fake.unmountWithContextMutex.Lock()
+	defer fake.unmountWithContextMutex.Unlock()
+	fake.unmountWithContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) UnmountWithContextReturnsOnCall(i int, result1 error) {
+	fake.UnmountWithContextStub = nil
+	fake.unmountWithContextMutex.Lock()
+	defer fake.unmountWithContextMutex.Unlock()
+	if fake.unmountWithContextReturnsOnCall == nil {
+		fake.unmountWithContextReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unmountWithContextReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeManager) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ volman.Manager = new(FakeManager)